@@ -96,7 +96,7 @@
 //	---------                ---------
 //	Set                      []anytype
 //	array<anytype>           []anytype
-//	tuple                    struct
+//	tuple                    struct, map[int]interface{}
 //	named tuple              struct
 //	Object                   struct
 //	bool                     bool, edgedb.OptionalBool
@@ -108,27 +108,56 @@
 //	                         edgedb.OptionalLocalDateTime
 //	cal::local_date          edgedb.LocalDate, edgedb.OptionalLocalDate
 //	cal::local_time          edgedb.LocalTime, edgedb.OptionalLocalTime
-//	duration                 edgedb.Duration, edgedb.OptionalDuration
+//	duration                 edgedb.Duration, edgedb.OptionalDuration,
+//	                         time.Duration
 //	cal::relative_duration   edgedb.RelativeDuration,
 //	                         edgedb.OptionalRelativeDuration
 //	float32                  float32, edgedb.OptionalFloat32
 //	float64                  float64, edgedb.OptionalFloat64
 //	int16                    int16, edgedb.OptionalFloat16
 //	int32                    int32, edgedb.OptionalInt16
-//	int64                    int64, edgedb.OptionalInt64
+//	int64                    int64, edgedb.OptionalInt64, int
+//	sequence                 int64, edgedb.OptionalInt64
 //	uuid                     edgedb.UUID, edgedb.OptionalUUID
 //	json                     []byte, edgedb.OptionalBytes
+//	                         or any type accepted by json.Unmarshal
 //	bigint                   *big.Int, edgedb.OptionalBigInt
-//
-//	decimal                  user defined (see Custom Marshalers)
+//	decimal                  string, edgedb.OptionalStr
+//	                         or user defined (see Custom Marshalers)
+//
+// The std::pg scalars returned by SQL queries are decoded like their EdgeDB
+// equivalents: pg::timestamptz as datetime, pg::timestamp as
+// cal::local_datetime, pg::date as cal::local_date, pg::interval as
+// cal::relative_duration and pg::json as json.
+//
+// Decimals are represented as strings that keep every digit of the decimal's
+// scale and the sign of negative zero, e.g. "-0.00".
+//
+// Numbers in json values decoded into interface{} are float64 by default,
+// which loses precision for large integers. Call
+// edgedb.UseJSONNumber(true) to decode them as json.Number instead.
+// Numbers sent as json strings to keep their precision, such as bigint
+// values, can be decoded into integer, float and *big.Int types.
+// uuids in json are hyphenated strings which can be decoded into
+// edgedb.UUID, or into edgedb.CompactUUID to keep them in their compact form
+// of 32 hex digits without hyphens.
+// A json null is decoded as nil into pointers, slices, maps and interface{}
+// and as the zero value into other types.
+//
+// cal::local_datetime values can be decoded into time.Time after calling
+// edgedb.UseLocalDateTimeLocation(loc). The decoded time has the same wall
+// clock reading as the local datetime in the given location.
 //
 // Note that EdgeDB's std::duration type is represented in int64 microseconds
 // while go's time.Duration type is int64 nanoseconds. It is incorrect to cast
-// one directly to the other.
+// one directly to the other. Durations decoded into time.Duration are
+// converted, values that do not fit in time.Duration return a
+// NumericOutOfRangeError.
 //
 // Shape fields that are not required must use optional types for receiving
 // query results. The edgedb.Optional struct can be embedded to make structs
-// optional.
+// optional. Pointers to any of the types above can also be used, missing
+// values are decoded as nil.
 //
 //	type User struct {
 //	    edgedb.Optional
@@ -172,6 +201,15 @@
 // Interfaces for user defined marshaler/unmarshalers  are documented in the
 // internal/marshal package.
 //
+// Scalars can also be decoded into types that implement
+// encoding.TextUnmarshaler. UnmarshalText receives the value's text
+// representation, e.g. the hyphenated form of a uuid.
+//
+// Arguments for str and enum parameters are encoded with the first of these
+// that the argument implements: StrMarshaler, encoding.TextMarshaler and
+// fmt.Stringer. Other types whose underlying type is string are encoded as
+// that string.
+//
 // [EdgeDB]: https://www.edgedb.com
 // [json]: https://www.edgedb.com/docs/edgeql/insert#bulk-inserts
 // [client connection docs]: https://www.edgedb.com/docs/clients/connection
@@ -26,17 +26,36 @@ import (
 )
 
 const (
+	// AtLeastOne is the cardinality of results with one or more elements.
+	AtLeastOne = edgedb.AtLeastOne
+
+	// AtMostOne is the cardinality of results with zero or one element.
+	AtMostOne = edgedb.AtMostOne
+
 	// DecodeEmptySetsAsNil decodes empty sets as nil slices (default)
 	DecodeEmptySetsAsNil = codecs.DecodeEmptySetsAsNil
 
 	// DecodeEmptySetsAsEmpty decodes empty sets as empty slices
 	DecodeEmptySetsAsEmpty = codecs.DecodeEmptySetsAsEmpty
 
+	// Many is the cardinality of results with any number of elements.
+	Many = edgedb.Many
+
 	// NetworkError indicates that the transaction was interupted
 	// by a network error.
 	NetworkError = edgedb.NetworkError
 
-	// Serializable is the only isolation level
+	// NoResult is the cardinality of commands that do not return a result.
+	NoResult = edgedb.NoResult
+
+	// One is the cardinality of results with exactly one element.
+	One = edgedb.One
+
+	// RepeatableRead runs transactions with REPEATABLE READ isolation.
+	// It requires EdgeDB 6.0 or later.
+	RepeatableRead = edgedb.RepeatableRead
+
+	// Serializable is the default isolation level
 	Serializable = edgedb.Serializable
 
 	// TLSModeDefault makes security mode inferred from other options
@@ -58,9 +77,20 @@ const (
 )
 
 type (
+	// AuthParameters are the SCRAM parameters that the server chose
+	// when a connection authenticated.
+	AuthParameters = edgedb.AuthParameters
+
+	// Cardinality is the result cardinality for a command.
+	Cardinality = edgedb.Cardinality
+
 	// Client is a connection pool and is safe for concurrent use.
 	Client = edgedb.Client
 
+	// CompactUUID is a UUID represented in its compact form,
+	// 32 hex digits without hyphens. It unmarshals from both forms.
+	CompactUUID = edgedbtypes.CompactUUID
+
 	// DateDuration represents the elapsed time between two dates in a fuzzy human
 	// way.
 	DateDuration = edgedbtypes.DateDuration
@@ -82,6 +112,13 @@ type (
 	// that can run queries on an EdgeDB database.
 	Executor = edgedb.Executor
 
+	// FieldInfo describes the struct field that a shape field is decoded into.
+	FieldInfo = codecs.FieldInfo
+
+	// FieldResolver returns the struct fields of typ keyed by shape field name.
+	// It returns nil for types that it does not know about.
+	FieldResolver = codecs.FieldResolver
+
 	// IsolationLevel documentation can be found here
 	// https://www.edgedb.com/docs/reference/edgeql/tx_start#parameters
 	IsolationLevel = edgedb.IsolationLevel
@@ -216,6 +253,16 @@ type (
 	// Options for connecting to an EdgeDB server
 	Options = edgedb.Options
 
+	// PrepareOptions configures how Client.Prepare() prepares a query.
+	PrepareOptions = edgedb.PrepareOptions
+
+	// PreparedStatement is a query that has been parsed by the server.
+	// Running a PreparedStatement reuses the descriptors and codecs
+	// from Client.Prepare() so the query is not parsed again.
+	// A PreparedStatement is safe for concurrent use
+	// and runs on any of the client's connections.
+	PreparedStatement = edgedb.PreparedStatement
+
 	// RangeDateTime is an interval of time.Time values.
 	RangeDateTime = edgedbtypes.RangeDateTime
 
@@ -243,6 +290,7 @@ type (
 
 	// RetryBackoff returns the duration to wait after the nth attempt
 	// before making the next attempt when retrying a transaction.
+	// The wait is interrupted if the query's context is done.
 	RetryBackoff = edgedb.RetryBackoff
 
 	// RetryCondition represents scenarios that can cause a transaction
@@ -450,15 +498,56 @@ var (
 	NewRetryRule = edgedb.NewRetryRule
 
 	// NewTxOptions returns the default TxOptions value.
+	// The isolation level is the client's default unless it is set with
+	// TxOptions.WithIsolation().
 	NewTxOptions = edgedb.NewTxOptions
 
 	// ParseUUID parses s into a UUID or returns an error.
 	ParseUUID = edgedbtypes.ParseUUID
 
+	// TryConnect connects to the server, authenticates and then immediately
+	// closes the connection. It returns nil if the server is ready to accept
+	// queries. This is useful for readiness probes and scripts that wait for a
+	// server to become available. Only a single connection attempt is made and
+	// the context deadline is respected.
+	TryConnect = edgedb.TryConnect
+
 	// UseEmptySetDecodingMode sets the decoding mode for empty sets.
 	UseEmptySetDecodingMode = codecs.SetDecodingMode
 
+	// UseFieldResolver sets the resolver that is consulted before edgedb tags
+	// and field names when decoding objects into structs. Shape fields missing
+	// from the resolver's mapping are matched by tag and name as usual.
+	UseFieldResolver = codecs.SetFieldResolver
+
+	// UseJSONNumber sets whether numbers in json values decoded into
+	// interface{} are represented as json.Number instead of float64.
+	// The default is float64.
+	UseJSONNumber = codecs.SetJSONUseNumber
+
+	// UseLocalDateTimeLocation sets the location that cal::local_datetime
+	// values are attached to when they are decoded into time.Time. The
+	// location is read for every decoded value so it can be changed at any
+	// time. Decoding into time.Time fails while the location is nil, which is
+	// the default.
+	UseLocalDateTimeLocation = codecs.SetLocalDateTimeLocation
+
 	// WarningsAsErrors is an edgedb.WarningHandler that returns warnings as
 	// errors.
 	WarningsAsErrors = edgedb.WarningsAsErrors
+
+	// WithExpectedCardinality returns a copy of ctx that asserts the cardinality
+	// of queries run with the returned context. The cardinality the server infers
+	// for the query must be compatible with card, for example a query inferred as
+	// One satisfies AtMostOne but a query inferred as Many does not. A
+	// ResultCardinalityMismatchError is returned before the query is executed if
+	// the assertion does not hold.
+	WithExpectedCardinality = edgedb.WithExpectedCardinality
+
+	// WithQueryAnnotations returns a copy of ctx that carries annotations.
+	// Annotations are sent to the server as headers with each query that is run
+	// with the returned context. They are merged with the annotations set on the
+	// client with Client.WithAnnotation(), values in ctx take precedence when both
+	// define the same key.
+	WithQueryAnnotations = edgedb.WithQueryAnnotations
 )
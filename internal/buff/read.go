@@ -32,6 +32,10 @@ type Reader struct {
 	Err     error
 	Buf     []byte
 	MsgType uint8
+
+	// scratch holds messages that span more than one socket read.
+	// It is grown as needed and reused for the lifetime of the reader.
+	scratch []byte
 }
 
 // NewReader returns a new Reader.
@@ -45,6 +49,19 @@ func SimpleReader(buf []byte) *Reader {
 	return r
 }
 
+// Reset makes the reader operate on buf as if it was created with
+// SimpleReader. Resetting an existing reader avoids allocating a new one.
+// Reset panics if called on a reader created with NewReader().
+func (r *Reader) Reset(buf []byte) {
+	if r.toBeDeserialized != nil {
+		panic("called reset on a socket reader")
+	}
+
+	r.Buf = buf[:len(buf):len(buf)]
+	r.Err = nil
+	r.MsgType = 0
+}
+
 // Next advances the reader to the next message.
 // Next returns false when the reader doesn't own any socket data
 // and a signal is received on doneReadingSignal,
@@ -135,7 +152,18 @@ func (r *Reader) feed(n int) error {
 	}
 
 	m := min(n, len(r.data.Buf))
-	r.Buf = r.data.Buf[:m]
+	if m == n {
+		r.Buf = r.data.Buf[:m]
+		r.data.Buf = r.data.Buf[m:]
+		return nil
+	}
+
+	// The message spans more than one socket read,
+	// reassemble it in the scratch buffer.
+	if cap(r.scratch) < n {
+		r.scratch = make([]byte, 0, n)
+	}
+	r.Buf = append(r.scratch[:0], r.data.Buf[:m]...)
 	r.data.Buf = r.data.Buf[m:]
 
 	for len(r.Buf) < n {
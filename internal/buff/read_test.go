@@ -215,3 +215,82 @@ func BenchmarkAssignBuf(b *testing.B) {
 		r.Buf = data
 	}
 }
+
+func TestNextSpanningMessage(t *testing.T) {
+	toBeDeserialized := make(chan *soc.Data, 4)
+	r := NewReader(toBeDeserialized)
+
+	for i := 0; i < 2; i++ {
+		toBeDeserialized <- &soc.Data{Buf: []byte{0xa, 0, 0, 0, 8, 1, 2}}
+		toBeDeserialized <- &soc.Data{Buf: []byte{3, byte(i)}}
+
+		require.True(t, r.Next(nil))
+		assert.Equal(t, uint8(0xa), r.MsgType)
+		assert.Equal(t, uint32(0x01020300|uint32(i)), r.PopUint32())
+	}
+
+	doneReadingSignal := make(chan struct{}, 1)
+	doneReadingSignal <- struct{}{}
+	assert.False(t, r.Next(doneReadingSignal))
+}
+
+func TestReset(t *testing.T) {
+	r := SimpleReader([]byte{1, 2})
+	require.Equal(t, uint16(0x102), r.PopUint16())
+
+	r.Reset([]byte{3})
+	require.Equal(t, uint8(3), r.PopUint8())
+	assert.Panics(t, func() { r.PopUint8() })
+
+	assert.Panics(t, func() {
+		NewReader(make(chan *soc.Data)).Reset([]byte{})
+	})
+}
+
+// BenchmarkNextSpanningMessage reads b.N messages that are each split across
+// two socket reads.
+func BenchmarkNextSpanningMessage(b *testing.B) {
+	msg := newBenchmarkMessage(1024)
+	// The reader holds on to the last chunk until the next call to Next,
+	// so alternate between two sets of chunks.
+	chunks := [2][2]soc.Data{}
+	toBeDeserialized := make(chan *soc.Data, 2)
+	r := NewReader(toBeDeserialized)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		head, tail := &chunks[i%2][0], &chunks[i%2][1]
+		head.Buf = msg[:512]
+		tail.Buf = msg[512:]
+		toBeDeserialized <- head
+		toBeDeserialized <- tail
+
+		r.Next(nil)
+		r.DiscardMessage()
+	}
+}
+
+func BenchmarkSimpleReader(b *testing.B) {
+	buf := newBenchmarkMessage(64)
+	var r *Reader
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r = SimpleReader(buf)
+		r.Discard(len(buf))
+	}
+}
+
+func BenchmarkReset(b *testing.B) {
+	buf := newBenchmarkMessage(64)
+	r := SimpleReader(nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Reset(buf)
+		r.Discard(len(buf))
+	}
+}
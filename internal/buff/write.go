@@ -18,10 +18,16 @@ package buff
 
 import (
 	"encoding/binary"
+	"fmt"
+	"math"
 
 	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
 )
 
+// maxStringLen is the largest string length
+// that can be encoded in the protocol's int32 length prefix.
+var maxStringLen = math.MaxInt32
+
 // Writer is a write buffer.
 type Writer struct {
 	buf     []byte
@@ -86,7 +92,14 @@ func (w *Writer) PushBytes(val []byte) {
 }
 
 // PushString writes a string to the buffer.
+// PushString panics if the string is too long to be encoded.
 func (w *Writer) PushString(val string) {
+	if len(val) > maxStringLen {
+		panic(fmt.Sprintf(
+			"cannot push string: length %v exceeds the maximum of %v bytes",
+			len(val), maxStringLen))
+	}
+
 	w.PushUint32(uint32(len(val)))
 	w.PushBytes([]byte(val))
 }
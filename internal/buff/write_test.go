@@ -0,0 +1,44 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushString(t *testing.T) {
+	w := NewWriter(nil)
+	w.PushString("abc")
+	assert.Equal(t, []byte{0, 0, 0, 3, 'a', 'b', 'c'}, w.buf)
+}
+
+func TestPushStringTooLong(t *testing.T) {
+	defer func(n int) { maxStringLen = n }(maxStringLen)
+	maxStringLen = 8
+
+	w := NewWriter(nil)
+	w.PushString(strings.Repeat("a", 8))
+
+	expected := "cannot push string: " +
+		"length 9 exceeds the maximum of 8 bytes"
+	assert.PanicsWithValue(t, expected, func() {
+		w.PushString(strings.Repeat("a", 9))
+	})
+}
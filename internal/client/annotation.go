@@ -0,0 +1,87 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edgedb
+
+import (
+	"context"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/header"
+	"golang.org/x/exp/slices"
+)
+
+type annotationsKey struct{}
+
+// WithQueryAnnotations returns a copy of ctx that carries annotations.
+// Annotations are sent to the server as headers with each query that is run
+// with the returned context. They are merged with the annotations set on the
+// client with Client.WithAnnotation(), values in ctx take precedence when both
+// define the same key.
+func WithQueryAnnotations(
+	ctx context.Context,
+	annotations map[string]string,
+) context.Context {
+	merged := make(map[string]string, len(annotations))
+	if a, ok := ctx.Value(annotationsKey{}).(map[string]string); ok {
+		for k, v := range a {
+			merged[k] = v
+		}
+	}
+
+	for k, v := range annotations {
+		merged[k] = v
+	}
+
+	return context.WithValue(ctx, annotationsKey{}, merged)
+}
+
+// mergeAnnotations returns the client annotations overridden by any
+// annotations set on ctx.
+func mergeAnnotations(
+	ctx context.Context,
+	annotations map[string]string,
+) header.Header1pX {
+	a, _ := ctx.Value(annotationsKey{}).(map[string]string)
+	if len(a) == 0 && len(annotations) == 0 {
+		return nil
+	}
+
+	merged := make(header.Header1pX, len(annotations)+len(a))
+	for k, v := range annotations {
+		merged[k] = v
+	}
+
+	for k, v := range a {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+func writeHeaders1pX(w *buff.Writer, headers header.Header1pX) {
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	w.PushUint16(uint16(len(keys)))
+	for _, k := range keys {
+		w.PushString(k)
+		w.PushString(headers[k])
+	}
+}
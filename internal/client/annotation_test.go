@@ -0,0 +1,98 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edgedb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/header"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func popAnnotations(body []byte) header.Header1pX {
+	r := buff.SimpleReader(body)
+	n := int(r.PopUint16())
+	annotations := make(header.Header1pX, n)
+	for i := 0; i < n; i++ {
+		annotations[r.PopString()] = r.PopString()
+	}
+	return annotations
+}
+
+func TestAnnotationsMerged(t *testing.T) {
+	server := newMockServer(t)
+	server.SetResult("SELECT 'hi';", mockStrResult("hi"))
+
+	ctx := context.Background()
+	p, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	annotated := p.
+		WithAnnotation("app", "client").
+		WithAnnotation("tag", "client")
+	ctx = WithQueryAnnotations(ctx, map[string]string{
+		"tag":  "call",
+		"user": "call",
+	})
+
+	var result string
+	err = annotated.QuerySingle(ctx, "SELECT 'hi';", &result)
+	require.NoError(t, err)
+
+	expected := header.Header1pX{
+		"app":  "client",
+		"tag":  "call",
+		"user": "call",
+	}
+	msgs := server.Messages(Execute)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, expected, popAnnotations(msgs[0].Body))
+	msgs = server.Messages(Parse)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, expected, popAnnotations(msgs[0].Body))
+
+	// The original client is not annotated.
+	err = p.Execute(context.Background(), "SELECT 'hi';")
+	require.NoError(t, err)
+	msgs = server.Messages(Execute)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, header.Header1pX{}, popAnnotations(msgs[1].Body))
+}
+
+func TestWithQueryAnnotationsNested(t *testing.T) {
+	ctx := WithQueryAnnotations(context.Background(), map[string]string{
+		"a": "outer",
+		"b": "outer",
+	})
+	ctx = WithQueryAnnotations(ctx, map[string]string{"b": "inner"})
+
+	merged := mergeAnnotations(ctx, map[string]string{
+		"a": "client",
+		"c": "client",
+	})
+	assert.Equal(t, header.Header1pX{
+		"a": "outer",
+		"b": "inner",
+		"c": "client",
+	}, merged)
+
+	assert.Nil(t, mergeAnnotations(context.Background(), nil))
+}
@@ -0,0 +1,105 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edgedb
+
+import (
+	"context"
+	"sync"
+)
+
+// branchPools are the connection pools of every branch
+// that a client and the clients derived from it connect to.
+type branchPools struct {
+	mu sync.Mutex
+
+	// concurrency is Options.Concurrency.
+	concurrency int
+
+	// clients holds the client owning the pool of each branch.
+	clients map[string]*Client
+
+	// closed is true once any of the clients has been closed.
+	closed bool
+}
+
+// WithBranch returns a shallow copy of the client that connects to branch
+// with the same credentials and connection options. Each branch has its own
+// pool of connections which is shared by every client for that branch.
+// The returned client keeps the transaction options, retry options, state,
+// annotations and warning handler of p.
+//
+// Closing any of the clients closes the pools of every branch.
+func (p Client) WithBranch(branch string) (*Client, error) { // nolint:gocritic
+	p.branches.mu.Lock()
+	defer p.branches.mu.Unlock()
+
+	if p.branches.closed {
+		return nil, &interfaceError{msg: "client closed"}
+	}
+
+	pool, ok := p.branches.clients[branch]
+	if !ok {
+		cfg := *p.cfg
+		cfg.branch = branch
+		cfg.database = branch
+
+		pool = newClient(&cfg, p.branches)
+		p.branches.clients[branch] = pool
+	}
+
+	pool.potentialConnsMutext.Lock()
+	p.potentialConns = pool.potentialConns
+	pool.potentialConnsMutext.Unlock()
+
+	p.isClosed = pool.isClosed
+	p.isClosedMutex = pool.isClosedMutex
+	p.freeConns = pool.freeConns
+	p.potentialConnsMutext = pool.potentialConnsMutext
+	p.concurrency = pool.concurrency
+	p.cfg = pool.cfg
+	p.cacheCollection = pool.cacheCollection
+
+	return &p, nil
+}
+
+// closeBranches closes the pools of the other branches.
+func (p *Client) closeBranches(ctx context.Context) error {
+	p.branches.mu.Lock()
+	p.branches.closed = true
+	pools := make([]*Client, 0, len(p.branches.clients))
+	for _, pool := range p.branches.clients {
+		if pool.isClosed != p.isClosed {
+			pools = append(pools, pool)
+		}
+	}
+	p.branches.mu.Unlock()
+
+	var errs []error
+	for _, pool := range pools {
+		pool.isClosedMutex.Lock()
+		if *pool.isClosed {
+			pool.isClosedMutex.Unlock()
+			continue
+		}
+		*pool.isClosed = true
+		pool.isClosedMutex.Unlock()
+
+		errs = append(errs, pool.closeConns(ctx))
+	}
+
+	return wrapAll(errs...)
+}
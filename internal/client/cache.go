@@ -46,7 +46,9 @@ Optimistic execute flow:
 import (
 	"encoding/binary"
 	"reflect"
+	"time"
 
+	"github.com/edgedb/edgedb-go/internal/cache"
 	"github.com/edgedb/edgedb-go/internal/codecs"
 	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
 	"github.com/edgedb/edgedb-go/internal/header"
@@ -63,8 +65,9 @@ type codecPair struct {
 }
 
 type idPair struct {
-	in  types.UUID
-	out types.UUID
+	in   types.UUID
+	out  types.UUID
+	card Cardinality
 }
 
 type queryKey struct {
@@ -86,6 +89,10 @@ func makeKey(q *query) queryKey {
 }
 
 func (c *protocolConnection) getCachedTypeIDs(q *query) (*idPair, bool) {
+	if c.disableStatementCache {
+		return nil, false
+	}
+
 	if val, ok := c.typeIDCache.Get(makeKey(q)); ok {
 		x := val.(idPair)
 		return &x, true
@@ -135,3 +142,43 @@ func (c *reconnectingConn) getCachedCapabilities(q *query) (uint64, bool) {
 
 	return 0, false
 }
+
+// resultCache holds the Data messages of read only queries.
+// Entries are keyed by the query's Execute message
+// and expire ttl after they were added.
+type resultCache struct {
+	results *cache.Cache
+	ttl     time.Duration
+}
+
+type cachedResult struct {
+	expires time.Time
+	data    [][]byte
+}
+
+// newResultCache returns nil if size or ttl is not positive.
+func newResultCache(size int, ttl time.Duration) *resultCache {
+	if size <= 0 || ttl <= 0 {
+		return nil
+	}
+
+	return &resultCache{results: cache.New(size), ttl: ttl}
+}
+
+func (c *resultCache) get(key string) ([][]byte, bool) {
+	if val, ok := c.results.Get(key); ok {
+		x := val.(cachedResult)
+		if time.Now().Before(x.expires) {
+			return x.data, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *resultCache) put(key string, data [][]byte) {
+	c.results.Put(key, cachedResult{
+		expires: time.Now().Add(c.ttl),
+		data:    data,
+	})
+}
@@ -18,14 +18,78 @@ package edgedb
 
 //go:generate go run golang.org/x/tools/cmd/stringer@v0.25.0 -type Cardinality
 
+import (
+	"context"
+	"fmt"
+)
+
 // Cardinality is the result cardinality for a command.
 type Cardinality uint8
 
 // Cardinalities
 const (
-	NoResult   Cardinality = 0x6e
-	AtMostOne  Cardinality = 0x6f
-	One        Cardinality = 0x41
-	Many       Cardinality = 0x6d
+	// NoResult is the cardinality of commands that do not return a result.
+	NoResult Cardinality = 0x6e
+
+	// AtMostOne is the cardinality of results with zero or one element.
+	AtMostOne Cardinality = 0x6f
+
+	// One is the cardinality of results with exactly one element.
+	One Cardinality = 0x41
+
+	// Many is the cardinality of results with any number of elements.
+	Many Cardinality = 0x6d
+
+	// AtLeastOne is the cardinality of results with one or more elements.
 	AtLeastOne Cardinality = 0x4d
 )
+
+type expectedCardinalityKey struct{}
+
+// WithExpectedCardinality returns a copy of ctx that asserts the cardinality
+// of queries run with the returned context. The cardinality the server infers
+// for the query must be compatible with card, for example a query inferred as
+// One satisfies AtMostOne but a query inferred as Many does not. A
+// ResultCardinalityMismatchError is returned before the query is executed if
+// the assertion does not hold.
+func WithExpectedCardinality(
+	ctx context.Context,
+	card Cardinality,
+) context.Context {
+	return context.WithValue(ctx, expectedCardinalityKey{}, card)
+}
+
+func expectedCardinality(ctx context.Context) Cardinality {
+	card, _ := ctx.Value(expectedCardinalityKey{}).(Cardinality)
+	return card
+}
+
+// satisfies reports whether every result with cardinality c
+// also has the expected cardinality.
+func (c Cardinality) satisfies(expected Cardinality) bool {
+	switch expected {
+	case Many:
+		return true
+	case AtLeastOne:
+		return c == One || c == AtLeastOne
+	case AtMostOne:
+		return c == NoResult || c == One || c == AtMostOne
+	default:
+		return c == expected
+	}
+}
+
+// checkCardinality returns an error if the cardinality the server inferred
+// for q does not satisfy the cardinality asserted with
+// WithExpectedCardinality.
+func checkCardinality(q *query, card Cardinality) error {
+	if q.assertCard == 0 || card.satisfies(q.assertCard) {
+		return nil
+	}
+
+	return &resultCardinalityMismatchError{msg: fmt.Sprintf(
+		"the query has cardinality %v "+
+			"which does not match the asserted cardinality %v",
+		card,
+		q.assertCard)}
+}
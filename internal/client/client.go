@@ -25,6 +25,7 @@ import (
 
 	"github.com/edgedb/edgedb-go/internal/cache"
 	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+	"golang.org/x/exp/slices"
 )
 
 const defaultIdleConnectionTimeout = 30 * time.Second
@@ -55,11 +56,21 @@ type Client struct {
 	txOpts    TxOptions
 	retryOpts RetryOptions
 
+	// isolation is the transaction isolation level used when txOpts
+	// does not set one.
+	isolation IsolationLevel
+
 	cfg *connConfig
 	cacheCollection
 	state map[string]interface{}
 
+	// annotations are sent as headers with every query.
+	annotations map[string]string
+
 	warningHandler WarningHandler
+
+	// branches are the pools of the clients returned by WithBranch.
+	branches *branchPools
 }
 
 // CreateClient returns a new client. The client connects lazily. Call
@@ -88,13 +99,34 @@ func CreateClientDSN(_ context.Context, dsn string, opts Options) (*Client, erro
 		warningHandler = opts.WarningHandler
 	}
 
+	maxDials := defaultMaxConcurrentDials
+	if opts.MaxConcurrentDials > 0 {
+		maxDials = int(opts.MaxConcurrentDials)
+	}
+	cfg.dialSlots = make(chan struct{}, maxDials)
+
+	branches := &branchPools{
+		concurrency: int(opts.Concurrency),
+		clients:     make(map[string]*Client),
+	}
+
+	p := newClient(cfg, branches)
+	p.warningHandler = warningHandler
+	branches.clients[cfg.branch] = p
+
+	return p, nil
+}
+
+// newClient returns a client with an empty connection pool.
+func newClient(cfg *connConfig, branches *branchPools) *Client {
 	False := false
-	p := &Client{
+	return &Client{
 		isClosed:             &False,
 		isClosedMutex:        &sync.RWMutex{},
 		cfg:                  cfg,
 		txOpts:               NewTxOptions(),
-		concurrency:          int(opts.Concurrency),
+		isolation:            Serializable,
+		concurrency:          branches.concurrency,
 		freeConns:            make(chan func() *transactableConn, 1),
 		potentialConnsMutext: &sync.Mutex{},
 		retryOpts:            NewRetryOptions(),
@@ -104,17 +136,41 @@ func CreateClientDSN(_ context.Context, dsn string, opts Options) (*Client, erro
 			inCodecCache:      cache.New(1_000),
 			outCodecCache:     cache.New(1_000),
 			capabilitiesCache: cache.New(1_000),
+			resultCache: newResultCache(
+				cfg.resultCacheSize,
+				cfg.resultCacheTTL,
+			),
 		},
-		state:          make(map[string]interface{}),
-		warningHandler: warningHandler,
+		state:    make(map[string]interface{}),
+		branches: branches,
 	}
+}
 
-	return p, nil
+// TryConnect connects to the server, authenticates and then immediately
+// closes the connection. It returns nil if the server is ready to accept
+// queries. This is useful for readiness probes and scripts that wait for a
+// server to become available. Only a single connection attempt is made and
+// the context deadline is respected.
+func TryConnect(ctx context.Context, opts Options) error { // nolint:gocritic
+	cfg, err := parseConnectDSNAndArgs("", &opts, newCfgPaths())
+	if err != nil {
+		return err
+	}
+
+	conn, err := connectWithTimeout(
+		ctx,
+		cfg,
+		cacheCollection{serverSettings: cfg.serverSettings},
+	)
+	if err != nil {
+		return err
+	}
+
+	return conn.close()
 }
 
 func (p *Client) newConn(ctx context.Context) (*transactableConn, error) {
 	conn := transactableConn{
-		txOpts:    p.txOpts,
 		retryOpts: p.retryOpts,
 		reconnectingConn: &reconnectingConn{
 			cfg:             p.cfg,
@@ -211,7 +267,10 @@ type systemConfig struct {
 }
 
 func (p *Client) release(conn *transactableConn, err error) error {
-	if isClientConnectionError(err) {
+	// A connection whose transaction failed and was not rolled back would
+	// refuse every query, it is terminated instead of being reused.
+	if isClientConnectionError(err) ||
+		conn.conn.transactionState == inFailedTransaction {
 		p.potentialConns <- struct{}{}
 		return conn.Close()
 	}
@@ -274,6 +333,18 @@ func (p *Client) EnsureConnected(ctx context.Context) error {
 	return p.release(conn, nil)
 }
 
+// EnabledExtensions returns the protocol extensions that the server enabled
+// during the handshake. It returns nil if the client has not connected yet,
+// call Client.EnsureConnected() first.
+func (p *Client) EnabledExtensions() []string {
+	extensions, ok := p.serverSettings.GetOk("enabled_extensions")
+	if !ok {
+		return nil
+	}
+
+	return slices.Clone(extensions.([]string))
+}
+
 // Close closes all connections in the pool.
 // Calling close blocks until all acquired connections have been released,
 // and returns an error if called more than once.
@@ -286,6 +357,32 @@ func (p *Client) Close() error {
 	}
 	*p.isClosed = true
 
+	ctx := context.Background()
+	return wrapAll(p.closeConns(ctx), p.closeBranches(ctx))
+}
+
+// DrainAndClose gracefully shuts down the client. Unlike Close new queries
+// fail immediately with an InterfaceError instead of waiting for the client to
+// close, while queries that are already running are allowed to finish. Each
+// connection is terminated once it is released. If ctx is done before all
+// connections have been released the context's error is returned without
+// waiting for the remaining connections, they are terminated in the
+// background once they are released. It returns an error if the client is
+// already closed.
+func (p *Client) DrainAndClose(ctx context.Context) error {
+	p.isClosedMutex.Lock()
+	if *p.isClosed {
+		p.isClosedMutex.Unlock()
+		return &interfaceError{msg: "client closed"}
+	}
+	*p.isClosed = true
+	p.isClosedMutex.Unlock()
+
+	return wrapAll(p.closeConns(ctx), p.closeBranches(ctx))
+}
+
+// closeConns waits for every connection to be released and closes it.
+func (p *Client) closeConns(ctx context.Context) error {
 	p.potentialConnsMutext.Lock()
 	if p.potentialConns == nil {
 		// The client never made any connections.
@@ -295,7 +392,7 @@ func (p *Client) Close() error {
 	p.potentialConnsMutext.Unlock()
 
 	wg := sync.WaitGroup{}
-	errs := make([]error, p.concurrency)
+	errs := make([]error, p.concurrency+1)
 	for i := 0; i < p.concurrency; i++ {
 		select {
 		case acquireIfNotTimedout := <-p.freeConns:
@@ -308,6 +405,14 @@ func (p *Client) Close() error {
 				wg.Done()
 			}(i)
 		case <-p.potentialConns:
+		case <-ctx.Done():
+			// The client is already closed, the connections that are still
+			// in use are terminated when they are released.
+			go p.closeReleasedConns(p.concurrency - i)
+
+			errs[p.concurrency] = fmt.Errorf("edgedb: %w", ctx.Err())
+			wg.Wait()
+			return wrapAll(errs...)
 		}
 	}
 
@@ -315,7 +420,26 @@ func (p *Client) Close() error {
 	return wrapAll(errs...)
 }
 
-// Execute an EdgeQL command (or commands).
+// closeReleasedConns closes the next n connections as they are released.
+func (p *Client) closeReleasedConns(n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case acquireIfNotTimedout := <-p.freeConns:
+			conn := acquireIfNotTimedout()
+			if conn == nil {
+				continue
+			}
+
+			if e := conn.Close(); e != nil {
+				log.Println("error while closing released connection:", e)
+			}
+		case <-p.potentialConns:
+		}
+	}
+}
+
+// Execute an EdgeQL command (or commands). Arguments are passed
+// positionally or as a single map of named arguments, like they are to Query.
 func (p *Client) Execute(
 	ctx context.Context,
 	cmd string,
@@ -332,6 +456,7 @@ func (p *Client) Execute(
 		args,
 		conn.capabilities1pX(),
 		copyState(p.state),
+		mergeAnnotations(ctx, p.annotations),
 		nil,
 		true,
 		p.warningHandler,
@@ -357,7 +482,16 @@ func (p *Client) Query(
 	}
 
 	err = runQuery(
-		ctx, conn, "Query", cmd, out, args, p.state, p.warningHandler)
+		ctx,
+		conn,
+		"Query",
+		cmd,
+		out,
+		args,
+		p.state,
+		p.annotations,
+		p.warningHandler,
+	)
 	return firstError(err, p.release(conn, err))
 }
 
@@ -384,6 +518,7 @@ func (p *Client) QuerySingle(
 		out,
 		args,
 		p.state,
+		p.annotations,
 		p.warningHandler,
 	)
 	return firstError(err, p.release(conn, err))
@@ -409,6 +544,7 @@ func (p *Client) QueryJSON(
 		out,
 		args,
 		p.state,
+		p.annotations,
 		p.warningHandler,
 	)
 	return firstError(err, p.release(conn, err))
@@ -436,6 +572,7 @@ func (p *Client) QuerySingleJSON(
 		out,
 		args,
 		p.state,
+		p.annotations,
 		p.warningHandler,
 	)
 	return firstError(err, p.release(conn, err))
@@ -454,7 +591,16 @@ func (p *Client) QuerySQL(
 	}
 
 	err = runQuery(
-		ctx, conn, "QuerySQL", cmd, out, args, p.state, p.warningHandler)
+		ctx,
+		conn,
+		"QuerySQL",
+		cmd,
+		out,
+		args,
+		p.state,
+		p.annotations,
+		p.warningHandler,
+	)
 	return firstError(err, p.release(conn, err))
 }
 
@@ -475,6 +621,7 @@ func (p *Client) ExecuteSQL(
 		args,
 		conn.capabilities1pX(),
 		copyState(p.state),
+		mergeAnnotations(ctx, p.annotations),
 		nil,
 		true,
 		p.warningHandler,
@@ -487,6 +634,25 @@ func (p *Client) ExecuteSQL(
 	return firstError(err, p.release(conn, err))
 }
 
+// DescribeSchema returns the schema of the current database as DDL.
+func (p *Client) DescribeSchema(ctx context.Context) (string, error) {
+	var ddl string
+	err := p.QuerySingle(ctx, "DESCRIBE SCHEMA AS DDL", &ddl)
+	return ddl, err
+}
+
+// ListDatabases returns the names of the databases on the server
+// sorted alphabetically.
+func (p *Client) ListDatabases(ctx context.Context) ([]string, error) {
+	var names []string
+	err := p.Query(
+		ctx,
+		"WITH name := sys::Database.name SELECT name ORDER BY name",
+		&names,
+	)
+	return names, err
+}
+
 // Tx runs an action in a transaction retrying failed actions
 // if they might succeed on a subsequent attempt.
 //
@@ -501,12 +667,22 @@ func (p *Client) ExecuteSQL(
 // If either field is unset (see RetryRule) then the default rule is used.
 // If the object's default is unset the fall back is 3 attempts
 // and exponential backoff.
+//
+// If ctx is done when the action returns the transaction is rolled back
+// and the context's error is returned together with the action's error.
+// If the rollback fails its error is returned as well and the connection
+// is closed instead of being reused.
 func (p *Client) Tx(ctx context.Context, action TxBlock) error {
 	conn, err := p.acquire(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = conn.tx(ctx, action, p.state, p.warningHandler)
+	opts := p.txOpts
+	if opts.isolation == "" {
+		opts.isolation = p.isolation
+	}
+
+	err = conn.tx(ctx, action, opts, p.state, p.annotations, p.warningHandler)
 	return firstError(err, p.release(conn, err))
 }
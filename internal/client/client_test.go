@@ -21,7 +21,9 @@ import (
 	"errors"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/edgedb/edgedb-go/internal/buff"
 	"github.com/edgedb/edgedb-go/internal/edgedbtypes"
 	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
 	"github.com/stretchr/testify/assert"
@@ -314,3 +316,325 @@ func TestConcurentClientUsage(t *testing.T) {
 
 	done.Wait()
 }
+
+func TestDescribeSchemaMock(t *testing.T) {
+	server := newMockServer(t)
+	ddl := "CREATE MODULE default IF NOT EXISTS;"
+	server.SetResult("DESCRIBE SCHEMA AS DDL", mockStrResult(ddl))
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	result, err := client.DescribeSchema(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ddl, result)
+	assert.Equal(t, []string{"DESCRIBE SCHEMA AS DDL"}, server.Commands())
+}
+
+func TestListDatabasesMock(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "WITH name := sys::Database.name SELECT name ORDER BY name"
+	server.SetResult(cmd, mockStrResult("edgedb", "inventory", "main"))
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	result, err := client.ListDatabases(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"edgedb", "inventory", "main"}, result)
+	assert.Equal(t, []string{cmd}, server.Commands())
+}
+
+func TestDrainAndCloseWaitsForQueries(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "SELECT 'slow'"
+	result := mockStrResult("slow")
+	result.Delay = 200 * time.Millisecond
+	server.SetResult(cmd, result)
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+
+	var (
+		slow  string
+		done  = make(chan error, 1)
+		ended time.Time
+	)
+	go func() {
+		err := client.QuerySingle(ctx, cmd, &slow)
+		ended = time.Now()
+		done <- err
+	}()
+
+	require.Eventually(t, func() bool { return len(server.Commands()) == 1 },
+		time.Second, time.Millisecond)
+
+	err = client.DrainAndClose(ctx)
+	closed := time.Now()
+	require.NoError(t, err)
+
+	require.NoError(t, <-done)
+	assert.Equal(t, "slow", slow)
+	assert.False(t, ended.After(closed), "query finished after close")
+	require.Eventually(t, func() bool {
+		return len(server.Messages(Terminate)) == 1
+	}, time.Second, time.Millisecond)
+
+	err = client.QuerySingle(ctx, cmd, &slow)
+	assert.EqualError(t, err, "edgedb.InterfaceError: client closed")
+	assert.EqualError(t, client.DrainAndClose(ctx),
+		"edgedb.InterfaceError: client closed")
+}
+
+func TestDrainAndCloseContextDone(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "SELECT 'slow'"
+	result := mockStrResult("slow")
+	result.Delay = 500 * time.Millisecond
+	server.SetResult(cmd, result)
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		var slow string
+		done <- client.QuerySingle(ctx, cmd, &slow)
+	}()
+
+	require.Eventually(t, func() bool { return len(server.Commands()) == 1 },
+		time.Second, time.Millisecond)
+
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err = client.DrainAndClose(timeout)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), err)
+	assert.Empty(t, server.Messages(Terminate))
+
+	// The connection is terminated once the query releases it.
+	require.NoError(t, <-done)
+	require.Eventually(t, func() bool {
+		return len(server.Messages(Terminate)) == 1
+	}, time.Second, time.Millisecond)
+	assert.EqualError(t, client.Close(),
+		"edgedb.InterfaceError: client closed")
+}
+
+func TestRetryCustomBackoff(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "SELECT 'conflict'"
+	result := mockStrResult("conflict")
+	result.ExecErrCode = 0x05_03_01_00 // TransactionConflictError
+	server.SetResult(cmd, result)
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	var attempts []int
+	backoff := func(n int) time.Duration {
+		attempts = append(attempts, n)
+		return time.Duration(n) * 10 * time.Millisecond
+	}
+	client = client.WithRetryOptions(NewRetryOptions().WithDefault(
+		NewRetryRule().WithAttempts(4).WithBackoff(backoff)))
+
+	var out string
+	start := time.Now()
+	err = client.QuerySingle(ctx, cmd, &out)
+	elapsed := time.Since(start)
+
+	var edbErr Error
+	require.True(t, errors.As(err, &edbErr), err)
+	assert.True(t, edbErr.Category(TransactionConflictError), err)
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+	assert.Len(t, server.Commands(), 4)
+	assert.GreaterOrEqual(t, elapsed, 60*time.Millisecond)
+}
+
+func TestRetryBackoffBoundedByContext(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "SELECT 'conflict'"
+	result := mockStrResult("conflict")
+	result.ExecErrCode = 0x05_03_01_00 // TransactionConflictError
+	server.SetResult(cmd, result)
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	client = client.WithRetryOptions(NewRetryOptions().WithDefault(
+		NewRetryRule().WithAttempts(10).WithBackoff(
+			func(int) time.Duration { return time.Minute })))
+
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	var out string
+	start := time.Now()
+	err = client.QuerySingle(timeout, cmd, &out)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Len(t, server.Commands(), 1)
+}
+
+func TestMaxConcurrentDials(t *testing.T) {
+	server := newMockServer(t)
+	server.handshakeDelay = 50 * time.Millisecond
+	cmd := "SELECT 'slow'"
+	result := mockStrResult("slow")
+	result.Delay = 100 * time.Millisecond
+	server.SetResult(cmd, result)
+
+	ctx := context.Background()
+	opts := server.Options()
+	opts.Concurrency = 8
+	opts.MaxConcurrentDials = 2
+	client, err := CreateClient(ctx, opts)
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var out string
+			assert.NoError(t, client.QuerySingle(ctx, cmd, &out))
+		}()
+	}
+	wg.Wait()
+
+	assert.Greater(t, len(server.Messages(ClientHandshake)), 2)
+	assert.LessOrEqual(t, server.MaxHandshakes(), 2)
+}
+
+func TestExecuteAdminister(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "ADMINISTER schema_repair()"
+	server.SetResult(cmd, mockResult{Card: NoResult, Status: "ADMINISTER"})
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	require.NoError(t, client.Execute(ctx, cmd))
+	assert.Equal(t, []string{cmd}, server.Commands())
+
+	// An AccessError sub-code that the client doesn't know about.
+	server.SetResult(cmd, mockResult{
+		Card:        NoResult,
+		ExecErrCode: 0x07_02_00_00,
+	})
+	err = client.Execute(ctx, cmd)
+
+	var edbErr Error
+	require.True(t, errors.As(err, &edbErr), err)
+	assert.True(t, edbErr.Category(AccessError), err)
+	assert.EqualError(t, err, "edgedb.AccessError: mock execute error")
+}
+
+func TestExecuteNamedArgs(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "INSERT Counter { value := <int64>$value }"
+	server.SetResult(cmd, mockInt64Kwargs(
+		mockResult{Card: NoResult, Status: "INSERT"}, "value"))
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	err = client.Execute(ctx, cmd, map[string]interface{}{"value": "1"})
+	assert.EqualError(t, err, "edgedb.InvalidArgumentError: "+
+		"expected args.value to be int64, edgedb.OptionalInt64 or "+
+		"Int64Marshaler got string")
+
+	err = client.Execute(ctx, cmd, map[string]interface{}{"value": int64(7)})
+	require.NoError(t, err)
+
+	msgs := server.Messages(Execute)
+	require.Len(t, msgs, 1)
+	r, _, _ := popQuery(msgs[0].Body)
+	r.Discard(16) // state descriptor id
+	r.PopBytes()  // state data
+	r.Discard(16) // input descriptor id
+	r.Discard(16) // output descriptor id
+	args := r.PopSlice(r.PopUint32())
+	assert.Equal(t, uint32(1), args.PopUint32(), "element count")
+	args.Discard(4) // reserved
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 7}, args.PopBytes())
+}
+
+func TestWithBranch(t *testing.T) {
+	server := newMockServer(t)
+	ctx := context.Background()
+
+	opts := server.Options()
+	opts.Database = ""
+	opts.Branch = "main"
+	client, err := CreateClient(ctx, opts)
+	require.NoError(t, err)
+
+	errHandled := errors.New("handled")
+	handler := func([]error) error { return errHandled }
+	client = client.
+		WithGlobals(map[string]interface{}{"a": int64(1)}).
+		WithConfig(map[string]interface{}{"b": true}).
+		WithTxOptions(NewTxOptions().WithIsolation(RepeatableRead)).
+		WithWarningHandler(handler)
+
+	feature, err := client.WithBranch("feature")
+	require.NoError(t, err)
+	assert.Equal(t, client.state, feature.state)
+	assert.Equal(t, client.txOpts, feature.txOpts)
+	assert.Equal(t, errHandled, feature.warningHandler(nil))
+	assert.Equal(t, "feature", feature.cfg.branch)
+
+	// Clients of the same branch share its pool.
+	other, err := client.WithoutGlobals("a").WithBranch("feature")
+	require.NoError(t, err)
+	assert.NotEqual(t, feature.state, other.state)
+	assert.Same(t, feature.cfg, other.cfg)
+	assert.Equal(t, feature.freeConns, other.freeConns)
+
+	mainBranch, err := feature.WithBranch("main")
+	require.NoError(t, err)
+	assert.Equal(t, client.state, mainBranch.state)
+	assert.Equal(t, client.freeConns, mainBranch.freeConns)
+
+	require.NoError(t, client.EnsureConnected(ctx))
+	require.NoError(t, feature.EnsureConnected(ctx))
+
+	msgs := server.Messages(ClientHandshake)
+	require.Len(t, msgs, 2)
+	for i, branch := range []string{"main", "feature"} {
+		r := buff.SimpleReader(msgs[i].Body)
+		r.Discard(4) // protocol version
+		params := make(map[string]string)
+		for n := r.PopUint16(); n > 0; n-- {
+			params[r.PopString()] = r.PopString()
+		}
+		assert.Equal(t, branch, params["branch"])
+		assert.Equal(t, branch, params["database"])
+	}
+
+	// Closing any client closes every branch.
+	require.NoError(t, feature.Close())
+	assert.EqualError(t, client.EnsureConnected(ctx),
+		"edgedb.InterfaceError: client closed")
+	assert.EqualError(t, client.Close(),
+		"edgedb.InterfaceError: client closed")
+
+	_, err = client.WithBranch("other")
+	assert.EqualError(t, err, "edgedb.InterfaceError: client closed")
+}
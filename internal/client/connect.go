@@ -20,6 +20,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 
 	"github.com/edgedb/edgedb-go/internal"
 	"github.com/edgedb/edgedb-go/internal/buff"
@@ -28,7 +30,10 @@ import (
 )
 
 func clientHandshakeMessage(
-	params map[string]string, alocatedMemory []byte) (*buff.Writer, error) {
+	params map[string]string,
+	version internal.ProtocolVersion,
+	alocatedMemory []byte,
+) (*buff.Writer, error) {
 	if len(params) > math.MaxUint16 {
 		return nil, errors.New("too many connection parameters")
 	}
@@ -41,8 +46,8 @@ func clientHandshakeMessage(
 	slices.Sort(paramKeys)
 	w := buff.NewWriter(alocatedMemory)
 	w.BeginMessage(uint8(ClientHandshake))
-	w.PushUint16(protocolVersionMax.Major)
-	w.PushUint16(protocolVersionMax.Minor)
+	w.PushUint16(version.Major)
+	w.PushUint16(version.Minor)
 	w.PushUint16(numParams)
 	for _, pk := range paramKeys {
 		w.PushString(pk)
@@ -64,12 +69,17 @@ func (c *protocolConnection) connect(r *buff.Reader, cfg *connConfig) error {
 		"secret_key": cfg.secretKey,
 	}
 
-	w, err := clientHandshakeMessage(params, c.writeMemory[:0])
+	proposed := protocolVersionMax
+	if cfg.protocolVersion != (internal.ProtocolVersion{}) {
+		proposed = cfg.protocolVersion
+	}
+
+	w, err := clientHandshakeMessage(params, proposed, c.writeMemory[:0])
 	if err != nil {
 		return err
 	}
 
-	c.protocolVersion = protocolVersionMax
+	c.protocolVersion = proposed
 
 	if err = c.soc.WriteAll(w.Unwrap()); err != nil {
 		return err
@@ -88,8 +98,10 @@ func (c *protocolConnection) connect(r *buff.Reader, cfg *connConfig) error {
 			// The client _MUST_ close the connection
 			// if the protocol version can't be supported.
 			// https://www.edgedb.com/docs/internals/protocol/overview
+			pinned := cfg.protocolVersion != (internal.ProtocolVersion{})
 			if protocolVersion.LT(protocolVersionMin) ||
-				protocolVersion.GT(protocolVersionMax) {
+				protocolVersion.GT(proposed) ||
+				pinned && protocolVersion != proposed {
 				_ = c.soc.Close()
 				msg := fmt.Sprintf(
 					"unsupported protocol version: %v.%v",
@@ -102,15 +114,16 @@ func (c *protocolConnection) connect(r *buff.Reader, cfg *connConfig) error {
 			c.protocolVersion = protocolVersion
 
 			n := r.PopUint16()
-			for i := uint16(0); i < n; i++ {
-				r.PopBytes() // extension name
+			extensions := make([]string, n)
+			for i := range extensions {
+				extensions[i] = r.PopString()
 				ignoreHeaders(r)
 			}
+			c.serverSettings.Set("enabled_extensions", extensions)
 		case ServerKeyData:
 			r.DiscardMessage() // key data
 		case ReadyForCommand:
-			ignoreHeaders(r)
-			r.Discard(1) // transaction state
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case Authentication:
 			if r.PopUint32() == 0 { // auth status
@@ -143,7 +156,82 @@ func (c *protocolConnection) connect(r *buff.Reader, cfg *connConfig) error {
 		}
 	}
 
-	return wrapAll(err, r.Err)
+	if err = wrapAll(err, r.Err); err != nil {
+		return err
+	}
+
+	c.adviseTLSSecurity(cfg)
+	return nil
+}
+
+// tlsSecurityRank orders the tls_security modes from least to most strict.
+var tlsSecurityRank = map[string]int{
+	"insecure":             0,
+	"no_host_verification": 1,
+	"strict":               2,
+}
+
+// adviseTLSSecurity passes a warning to the warning handler if the server
+// suggested a stricter tls_security mode than the default one in use.
+// The warning is advisory, the connection's TLS settings are not changed
+// and an error returned by the warning handler does not fail the connection.
+func (c *protocolConnection) adviseTLSSecurity(cfg *connConfig) {
+	suggested := c.suggestedTLSSecurity
+	if suggested == "" || !cfg.tlsSecurityDefault {
+		return
+	}
+
+	rank, ok := tlsSecurityRank[suggested]
+	if !ok || rank <= tlsSecurityRank[cfg.tlsSecurity] {
+		return
+	}
+
+	warningHandler := cfg.warningHandler
+	if warningHandler == nil {
+		warningHandler = LogWarnings
+	}
+
+	_ = warningHandler([]error{&clientError{msg: fmt.Sprintf(
+		"the server suggests tls_security=%v but the client is using "+
+			"the default tls_security=%v, consider setting tls_security=%v",
+		suggested, cfg.tlsSecurity, suggested)}})
+}
+
+// AuthParameters are the SCRAM parameters that the server chose
+// when a connection authenticated.
+type AuthParameters struct {
+	// Nonce is the combined client and server nonce
+	// from the server-first message.
+	Nonce string
+
+	// Iterations is the PBKDF2 iteration count.
+	Iterations int
+}
+
+// parseServerFirstMessage returns the auditable parameters
+// from a SCRAM server-first message.
+func parseServerFirstMessage(msg string) (AuthParameters, error) {
+	var params AuthParameters
+	for _, attr := range strings.Split(msg, ",") {
+		switch {
+		case strings.HasPrefix(attr, "r="):
+			params.Nonce = attr[2:]
+		case strings.HasPrefix(attr, "i="):
+			i, err := strconv.Atoi(attr[2:])
+			if err != nil {
+				return AuthParameters{}, fmt.Errorf(
+					"invalid SCRAM iteration count: %q", attr[2:])
+			}
+			params.Iterations = i
+		}
+	}
+
+	if params.Nonce == "" || params.Iterations == 0 {
+		return AuthParameters{}, fmt.Errorf(
+			"invalid SCRAM server-first message: %q", msg)
+	}
+
+	return params, nil
 }
 
 func (c *protocolConnection) authenticate(
@@ -171,6 +259,7 @@ func (c *protocolConnection) authenticate(
 		return e
 	}
 
+	var params AuthParameters
 	done := buff.NewSignal()
 
 	for r.Next(done.Chan) {
@@ -191,6 +280,12 @@ func (c *protocolConnection) authenticate(
 				return &authenticationError{msg: err.Error()}
 			}
 
+			params, err = parseServerFirstMessage(scramRcv)
+			if err != nil {
+				// the connection will not be usable after this x_x
+				return &authenticationError{msg: err.Error()}
+			}
+
 			done.Signal()
 		case ErrorResponse:
 			err = decodeErrorResponseMsg(r, "")
@@ -239,8 +334,7 @@ func (c *protocolConnection) authenticate(
 		case ServerKeyData:
 			r.DiscardMessage() // key data
 		case ReadyForCommand:
-			ignoreHeaders(r)
-			r.Discard(1) // transaction state
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case StateDataDescription:
 			if e := c.decodeStateDataDescription(r); e != nil {
@@ -256,7 +350,15 @@ func (c *protocolConnection) authenticate(
 		}
 	}
 
-	return wrapAll(err, r.Err)
+	if err != nil || r.Err != nil {
+		return wrapAll(err, r.Err)
+	}
+
+	if cfg.authHandler != nil {
+		cfg.authHandler(params)
+	}
+
+	return nil
 }
 
 func (c *protocolConnection) terminate() error {
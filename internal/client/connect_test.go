@@ -18,8 +18,14 @@ package edgedb
 
 import (
 	"context"
+	"io"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
 	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -57,13 +63,178 @@ func TestAuth(t *testing.T) {
 	assert.EqualError(t, err, msg)
 }
 
+func TestTryConnect(t *testing.T) {
+	server := newMockServer(t)
+	server.password = "secret"
+	ctx := context.Background()
+
+	err := TryConnect(ctx, server.Options())
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(server.Messages(Terminate)) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	opts := server.Options()
+	opts.Password.Set("wrong")
+	err = TryConnect(ctx, opts)
+	var edbErr Error
+	require.ErrorAs(t, err, &edbErr)
+	assert.True(t, edbErr.Category(AuthenticationError), err)
+}
+
+func TestAuthHandler(t *testing.T) {
+	server := newMockServer(t)
+	server.password = "secret"
+	ctx := context.Background()
+
+	var params []AuthParameters
+	opts := server.Options()
+	opts.AuthHandler = func(p AuthParameters) { params = append(params, p) }
+	require.NoError(t, TryConnect(ctx, opts))
+	require.Len(t, params, 1)
+	assert.Equal(t, 4096, params[0].Iterations)
+
+	// The combined nonce starts with the client nonce.
+	msgs := server.Messages(AuthenticationSASLInitialResponse)
+	require.Len(t, msgs, 1)
+	r := buff.SimpleReader(msgs[0].Body)
+	r.PopString() // method
+	clientFirst := r.PopString()
+	clientNonce := clientFirst[strings.Index(clientFirst, ",r=")+3:]
+	assert.True(t, strings.HasPrefix(params[0].Nonce, clientNonce),
+		"%q does not start with %q", params[0].Nonce, clientNonce)
+	assert.Greater(t, len(params[0].Nonce), len(clientNonce))
+
+	opts.Password.Set("wrong")
+	require.Error(t, TryConnect(ctx, opts))
+	assert.Len(t, params, 1, "failed authentication must not be reported")
+}
+
+func TestParseServerFirstMessage(t *testing.T) {
+	params, err := parseServerFirstMessage(
+		"r=abc123,s=c2FsdA==,i=10000")
+	require.NoError(t, err)
+	assert.Equal(t, AuthParameters{Nonce: "abc123", Iterations: 10000}, params)
+
+	_, err = parseServerFirstMessage("r=abc123,s=c2FsdA==,i=x")
+	assert.EqualError(t, err, `invalid SCRAM iteration count: "x"`)
+
+	_, err = parseServerFirstMessage("s=c2FsdA==,i=4096")
+	assert.EqualError(t, err,
+		`invalid SCRAM server-first message: "s=c2FsdA==,i=4096"`)
+}
+
+func TestSuggestedTLSSecurityWarning(t *testing.T) {
+	t.Setenv("EDGEDB_CLIENT_SECURITY", "insecure_dev_mode")
+	server := newMockServer(t)
+	server.parameters = map[string]string{"suggested_tls_security": "strict"}
+	ctx := context.Background()
+
+	var warnings []error
+	opts := server.Options()
+	opts.WarningHandler = func(w []error) error {
+		warnings = append(warnings, w...)
+		return nil
+	}
+	require.NoError(t, TryConnect(ctx, opts))
+	require.Len(t, warnings, 1)
+	assert.EqualError(t, warnings[0], "edgedb.ClientError: "+
+		"the server suggests tls_security=strict but the client is using "+
+		"the default tls_security=insecure, consider setting "+
+		"tls_security=strict")
+
+	// The warning is advisory even if the handler returns an error.
+	warnings = nil
+	handler := opts.WarningHandler
+	opts.WarningHandler = func(w []error) error {
+		_ = handler(w)
+		return WarningsAsErrors(w)
+	}
+	require.NoError(t, TryConnect(ctx, opts))
+	require.Len(t, warnings, 1)
+
+	// An explicitly configured tls_security is not second guessed.
+	warnings = nil
+	opts.TLSOptions.SecurityMode = TLSModeInsecure
+	require.NoError(t, TryConnect(ctx, opts))
+	assert.Empty(t, warnings)
+}
+
+func TestWithProtocolVersion(t *testing.T) {
+	server := newMockServer(t)
+	ctx := context.Background()
+
+	opts := server.Options().WithProtocolVersion(2, 0)
+	require.NoError(t, TryConnect(ctx, opts))
+
+	msgs := server.Messages(ClientHandshake)
+	require.Len(t, msgs, 1)
+	r := buff.SimpleReader(msgs[0].Body)
+	assert.Equal(t, uint16(2), r.PopUint16(), "major version")
+	assert.Equal(t, uint16(0), r.PopUint16(), "minor version")
+
+	opts = server.Options().WithProtocolVersion(0, 8)
+	err := TryConnect(ctx, opts)
+	assert.EqualError(t, err,
+		"edgedb.ConfigurationError: unsupported protocol version: 0.8")
+}
+
+func TestEnabledExtensions(t *testing.T) {
+	server := newMockServer(t)
+	server.extensions = []string{"pgvector"}
+	ctx := context.Background()
+
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	assert.Nil(t, client.EnabledExtensions())
+	require.NoError(t, client.EnsureConnected(ctx))
+	assert.Equal(t, []string{"pgvector"}, client.EnabledExtensions())
+}
+
+func TestTryConnectDeadline(t *testing.T) {
+	// a server that accepts connections but never responds
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close() // nolint:errcheck
+	go func() {
+		for {
+			conn, e := ln.Accept()
+			if e != nil {
+				return
+			}
+			go func() { _, _ = io.Copy(io.Discard, conn) }()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		100*time.Millisecond,
+	)
+	defer cancel()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	start := time.Now()
+	err = TryConnect(ctx, Options{
+		Host: addr.IP.String(),
+		Port: addr.Port,
+	}.WithPlaintext())
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	var edbErr Error
+	require.ErrorAs(t, err, &edbErr)
+	assert.True(t, edbErr.Category(ClientConnectionTimeoutError), err)
+}
+
 func TestCloudClientHandshakeMessage(t *testing.T) {
 	params := map[string]string{
 		"database":   "mydb",
 		"secret_key": "mysecret",
 		"user":       "myuser",
 	}
-	got, err := clientHandshakeMessage(params, []byte{})
+	got, err := clientHandshakeMessage(
+		params, protocolVersionMax, []byte{})
 	assert.NoError(t, err)
 	majorUpper, majorLower := convertUint16ToUint8(protocolVersionMax.Major)
 	minorUpper, minorLower := convertUint16ToUint8(protocolVersionMax.Minor)
@@ -105,3 +276,37 @@ func convertUint16ToUint8(value uint16) (uint8, uint8) {
 
 	return lowerByte, upperByte
 }
+
+func TestStateDescriptorNotRefetched(t *testing.T) {
+	id := types.UUID{0x57, 0xa7}
+	stateDataDescription := func(desc ...byte) *buff.Reader {
+		w := buff.NewWriter(nil)
+		w.PushUUID(id)
+		w.PushUint32(uint32(len(desc)))
+		w.PushBytes(desc)
+		return buff.SimpleReader(w.Unwrap())
+	}
+
+	// an input shape without any fields
+	shape := []byte{0, 0, 0, 19, uint8(descriptor.InputShape)}
+	shape = append(shape, id[:]...)
+	shape = append(shape, 0, 0)
+
+	conn := &protocolConnection{protocolVersion: protocolVersion3p0}
+	err := conn.decodeStateDataDescription(stateDataDescription(shape...))
+	require.NoError(t, err)
+	assert.Equal(t, id, conn.stateDescriptorID())
+	codec := conn.stateCodec
+
+	// The descriptor is not decoded again when the id is unchanged.
+	err = conn.decodeStateDataDescription(stateDataDescription(0xff))
+	require.NoError(t, err)
+	assert.Same(t, codec, conn.stateCodec)
+
+	// Other connections reuse the codec built for the id.
+	other := &protocolConnection{protocolVersion: protocolVersion3p0}
+	err = other.decodeStateDataDescription(stateDataDescription(0xff))
+	require.NoError(t, err)
+	assert.Equal(t, id, other.stateDescriptorID())
+	assert.Same(t, codec, other.stateCodec)
+}
@@ -36,6 +36,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/edgedb/edgedb-go/internal"
 	"github.com/edgedb/edgedb-go/internal/edgedbtypes"
 	"github.com/edgedb/edgedb-go/internal/snc"
 	"github.com/sigurn/crc16"
@@ -70,6 +71,26 @@ type connConfig struct {
 	tlsServerName      string
 	serverSettings     *snc.ServerSettings
 	secretKey          string
+	plaintext          bool
+	authHandler        func(AuthParameters)
+	protocolVersion    internal.ProtocolVersion
+	warningHandler     WarningHandler
+	queryPreprocessor  func(string) string
+
+	// tlsSecurityDefault is true if tlsSecurity was not set explicitly.
+	tlsSecurityDefault bool
+
+	// disableStatementCache is true if queries must always be parsed.
+	disableStatementCache bool
+
+	// resultCacheSize and resultCacheTTL configure the result cache.
+	// The result cache is disabled if either is not positive.
+	resultCacheSize int
+	resultCacheTTL  time.Duration
+
+	// dialSlots limits how many connections are established at once.
+	// Connections are established without a limit if dialSlots is nil.
+	dialSlots chan struct{}
 }
 
 func (c *connConfig) tlsConfig() (*tls.Config, error) {
@@ -1019,6 +1040,18 @@ func (r *configResolver) config(opts *Options) (*connConfig, error) {
 		}
 	}
 
+	pinned := opts.protocolVersion
+	if pinned != (internal.ProtocolVersion{}) &&
+		(pinned.LT(protocolVersionMin) || pinned.GT(protocolVersionMax)) {
+		return nil, fmt.Errorf(
+			"unsupported protocol version: %v.%v", pinned.Major, pinned.Minor)
+	}
+
+	if opts.plaintext && security == "strict" {
+		return nil, errors.New("EDGEDB_CLIENT_SECURITY=strict " +
+			"but a plaintext connection was requested")
+	}
+
 	if tlsSecurity == "default" {
 		if len(certData) == 0 {
 			tlsSecurity = "strict"
@@ -1043,8 +1076,18 @@ func (r *configResolver) config(opts *Options) (*connConfig, error) {
 		serverSettings:     r.serverSettings,
 		tlsCAData:          certData,
 		tlsSecurity:        tlsSecurity,
+		tlsSecurityDefault: r.tlsSecurity.val == nil,
 		tlsServerName:      tlsServerName,
 		secretKey:          secretKey,
+		plaintext:          opts.plaintext,
+		authHandler:        opts.AuthHandler,
+		protocolVersion:    opts.protocolVersion,
+		warningHandler:     opts.WarningHandler,
+		queryPreprocessor:  opts.QueryPreprocessor,
+
+		disableStatementCache: opts.disableStatementCache,
+		resultCacheSize:       opts.resultCacheSize,
+		resultCacheTTL:        opts.resultCacheTTL,
 	}, nil
 }
 
@@ -112,6 +112,7 @@ func TestConUtils(t *testing.T) {
 					serverSettings:     snc.NewServerSettings(),
 					waitUntilAvailable: 30 * time.Second,
 					tlsSecurity:        "strict",
+					tlsSecurityDefault: true,
 				},
 			},
 		},
@@ -134,6 +135,7 @@ func TestConUtils(t *testing.T) {
 					serverSettings:     snc.NewServerSettings(),
 					waitUntilAvailable: 30 * time.Second,
 					tlsSecurity:        "strict",
+					tlsSecurityDefault: true,
 				},
 			},
 		},
@@ -163,6 +165,7 @@ func TestConUtils(t *testing.T) {
 					serverSettings:     snc.NewServerSettings(),
 					waitUntilAvailable: 30 * time.Second,
 					tlsSecurity:        "strict",
+					tlsSecurityDefault: true,
 				},
 			},
 		},
@@ -195,6 +198,7 @@ func TestConUtils(t *testing.T) {
 					}),
 					waitUntilAvailable: 30 * time.Second,
 					tlsSecurity:        "strict",
+					tlsSecurityDefault: true,
 				},
 			},
 		},
@@ -218,6 +222,7 @@ func TestConUtils(t *testing.T) {
 					serverSettings:     snc.NewServerSettings(),
 					waitUntilAvailable: 30 * time.Second,
 					tlsSecurity:        "strict",
+					tlsSecurityDefault: true,
 				},
 			},
 		},
@@ -234,6 +239,7 @@ func TestConUtils(t *testing.T) {
 					serverSettings:     snc.NewServerSettings(),
 					waitUntilAvailable: 30 * time.Second,
 					tlsSecurity:        "strict",
+					tlsSecurityDefault: true,
 				},
 			},
 		},
@@ -317,6 +323,7 @@ func TestConUtils(t *testing.T) {
 					branch:             "db",
 					waitUntilAvailable: 30 * time.Second,
 					tlsSecurity:        "strict",
+					tlsSecurityDefault: true,
 				},
 			},
 		},
@@ -343,6 +350,7 @@ func TestConUtils(t *testing.T) {
 					branch:             "db",
 					waitUntilAvailable: 30 * time.Second,
 					tlsSecurity:        "strict",
+					tlsSecurityDefault: true,
 				},
 			},
 		},
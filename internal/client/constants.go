@@ -25,11 +25,16 @@ import (
 )
 
 var (
-	descCache = cache.New(1_000)
-	rnd       = snc.NewRand()
+	descCache       = cache.New(1_000)
+	stateCodecCache = cache.New(1_000)
+	rnd             = snc.NewRand()
 
 	defaultConcurrency = max(4, runtime.NumCPU())
 
+	// defaultMaxConcurrentDials limits how many connections
+	// a client establishes at the same time.
+	defaultMaxConcurrentDials = 4
+
 	protocolVersionMin  = protocolVersion0p13
 	protocolVersionMax  = protocolVersion3p0
 	protocolVersion0p13 = internal.ProtocolVersion{Major: 0, Minor: 13}
@@ -37,6 +42,7 @@ var (
 	protocolVersion2p0  = internal.ProtocolVersion{Major: 2, Minor: 0}
 	protocolVersion3p0  = internal.ProtocolVersion{Major: 3, Minor: 0}
 
+	capabilitiesModifications uint64 = 0x1
 	capabilitiesSessionConfig uint64 = 0x2
 	capabilitiesTransaction   uint64 = 0x4
 	capabilitiesDDL           uint64 = 0x8
@@ -19,6 +19,7 @@ package edgedb
 import (
 	"context"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/edgedb/edgedb-go/internal"
@@ -35,6 +36,9 @@ type cacheCollection struct {
 	inCodecCache      *cache.Cache
 	outCodecCache     *cache.Cache
 	capabilitiesCache *cache.Cache // nolint:structcheck
+
+	// resultCache is nil if results are not cached.
+	resultCache *resultCache
 }
 
 type protocolConnection struct {
@@ -48,6 +52,23 @@ type protocolConnection struct {
 
 	systemConfig systemConfig
 	stateCodec   codecs.Encoder
+
+	// elmReader is reused to decode Data message elements.
+	elmReader buff.Reader
+
+	// suggestedTLSSecurity is the tls_security mode
+	// the server recommends clients to use.
+	suggestedTLSSecurity string
+
+	// queryPreprocessor transforms query text before it is sent.
+	queryPreprocessor func(string) string
+
+	// disableStatementCache is true if queries must always be parsed.
+	disableStatementCache bool
+
+	// transactionState is the transaction state
+	// from the last ReadyForCommand message.
+	transactionState uint8
 }
 
 // connectWithTimeout makes a single attempt to connect to `addr`.
@@ -56,6 +77,15 @@ func connectWithTimeout(
 	cfg *connConfig,
 	caches cacheCollection,
 ) (*protocolConnection, error) {
+	if cfg.dialSlots != nil {
+		select {
+		case cfg.dialSlots <- struct{}{}:
+			defer func() { <-cfg.dialSlots }()
+		case <-ctx.Done():
+			return nil, wrapNetError(ctx.Err())
+		}
+	}
+
 	socket, err := connectAutoClosingSocket(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -72,6 +102,9 @@ func connectWithTimeout(
 		acquireReaderSignal: make(chan struct{}, 1),
 		readerChan:          make(chan *buff.Reader, 1),
 		cacheCollection:     caches,
+		queryPreprocessor:   cfg.queryPreprocessor,
+
+		disableStatementCache: cfg.disableStatementCache,
 	}
 
 	toBeDeserialized := make(chan *soc.Data, 2)
@@ -80,6 +113,7 @@ func connectWithTimeout(
 
 	err = conn.connect(r, cfg)
 	if err != nil {
+		_ = socket.Close()
 		return nil, err
 	}
 
@@ -182,6 +216,41 @@ func (c *protocolConnection) isClosed() bool {
 	return false
 }
 
+// notInTransaction is the transaction state
+// of a connection that is not in a transaction.
+const notInTransaction = 'I'
+
+// inFailedTransaction is the transaction state of a connection
+// whose transaction has failed and must be rolled back.
+const inFailedTransaction = 'E'
+
+// assertNotInFailedTransaction returns an error if the connection's
+// transaction has failed and q does not roll it back. The server would
+// reject the query anyway.
+func (c *protocolConnection) assertNotInFailedTransaction(q *query) error {
+	if c.transactionState != inFailedTransaction {
+		return nil
+	}
+
+	cmd := strings.ToUpper(strings.TrimSpace(q.cmd))
+	if strings.HasPrefix(cmd, "ROLLBACK") {
+		return nil
+	}
+
+	return errInFailedTransaction
+}
+
+// preprocess passes the query text through the query preprocessor.
+// A query is only preprocessed once, even if it is retried.
+func (c *protocolConnection) preprocess(q *query) {
+	if c.queryPreprocessor == nil || q.preprocessed {
+		return
+	}
+
+	q.cmd = c.queryPreprocessor(q.cmd)
+	q.preprocessed = true
+}
+
 func (c *protocolConnection) scriptFlow(ctx context.Context, q *query) error {
 	if q.lang == SQL && c.protocolVersion.LT(protocolVersion3p0) {
 		return &unsupportedFeatureError{
@@ -190,6 +259,12 @@ func (c *protocolConnection) scriptFlow(ctx context.Context, q *query) error {
 		}
 	}
 
+	if e := c.assertNotInFailedTransaction(q); e != nil {
+		return e
+	}
+
+	c.preprocess(q)
+
 	r, err := c.acquireReader(ctx)
 	if err != nil {
 		return err
@@ -224,6 +299,19 @@ func (c *protocolConnection) granularFlow(
 		}
 	}
 
+	if e := c.assertNotInFailedTransaction(q); e != nil {
+		return e
+	}
+
+	c.preprocess(q)
+
+	if q.stmt != nil && c.protocolVersion.LT(protocolVersion2p0) {
+		return &unsupportedFeatureError{
+			msg: "the server does not support prepared statements, " +
+				"upgrade to 5.0 or newer",
+		}
+	}
+
 	r, err := c.acquireReader(ctx)
 	if err != nil {
 		return err
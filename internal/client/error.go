@@ -26,6 +26,7 @@ import (
 	"syscall"
 
 	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/codecs"
 )
 
 var (
@@ -36,6 +37,13 @@ var (
 		"are not supported by the server. " +
 		"Upgrade your server to version 2.0 or greater " +
 		"to use these features."}
+
+	errInFailedTransaction = &interfaceError{msg: "cannot run the query; " +
+		"the transaction is in an error state and must be rolled back"}
+
+	// errorCodeMasks select the codes of an error's ancestors,
+	// from the closest to the most distant.
+	errorCodeMasks = [...]uint32{0xff_ff_ff_00, 0xff_ff_00_00, 0xff_00_00_00}
 )
 
 // ErrorTag is the argument type to Error.HasTag().
@@ -123,6 +131,26 @@ func decodeErrorResponseMsg(r *buff.Reader, query string) error {
 	return w.Err(query)
 }
 
+// errorFromCode returns the error for code. Error codes are hierarchical,
+// unknown codes are mapped to their closest known ancestor.
+func errorFromCode(code uint32, msg string) error {
+	if err := knownErrorFromCode(code, msg); err != nil {
+		return err
+	}
+
+	for _, mask := range errorCodeMasks {
+		if err := knownErrorFromCode(code&mask, msg); err != nil {
+			return err
+		}
+	}
+
+	return &unexpectedMessageError{
+		msg: fmt.Sprintf(
+			"invalid error code 0x%x with message %q", code, msg,
+		),
+	}
+}
+
 type wrappedManyError struct {
 	msg  string
 	errs []error
@@ -181,6 +209,15 @@ func isClientConnectionError(err error) bool {
 	return errors.As(err, &edbErr) && edbErr.Category(ClientConnectionError)
 }
 
+// wrapDecodeError converts codec errors into edgedb errors where possible.
+func wrapDecodeError(err error) error {
+	if errors.Is(err, codecs.ErrNumericOutOfRange) {
+		return &numericOutOfRangeError{err: err}
+	}
+
+	return err
+}
+
 func wrapNetError(err error) error {
 	var errEDB Error
 	var errNetOp *net.OpError
@@ -155,3 +155,23 @@ func TestWrapAllIs(t *testing.T) {
 	assert.True(t, errors.Is(err, errA))
 	assert.True(t, errors.Is(err, errB))
 }
+
+func TestErrorFromUnknownCode(t *testing.T) {
+	msg := "example error message"
+
+	err := errorFromCode(0x05_03_01_7f, msg)
+	var edbErr Error
+	require.True(t, errors.As(err, &edbErr))
+	assert.True(t, edbErr.Category(TransactionConflictError), err)
+
+	err = errorFromCode(0x04_7f_00_00, msg)
+	assert.EqualError(t, err, "edgedb.QueryError: "+msg)
+
+	err = errorFromCode(0x7f_00_00_00, msg)
+	assert.EqualError(t, err, "edgedb.UnexpectedMessageError: "+
+		"invalid error code 0x7f000000 with message \"example error message\"")
+
+	err = errorFromCode(0x7f_01_00_00, msg)
+	assert.EqualError(t, err, "edgedb.UnexpectedMessageError: "+
+		"invalid error code 0x7f010000 with message \"example error message\"")
+}
@@ -22,8 +22,6 @@
 
 package edgedb
 
-import "fmt"
-
 const (
 	ShouldRetry     ErrorTag = "SHOULD_RETRY"
 	ShouldReconnect ErrorTag = "SHOULD_RECONNECT"
@@ -4067,7 +4065,7 @@ func (e *internalClientError) HasTag(tag ErrorTag) bool {
 	}
 }
 
-func errorFromCode(code uint32, msg string) error {
+func knownErrorFromCode(code uint32, msg string) error {
 	switch code {
 	case 0x01_00_00_00:
 		return &internalServerError{msg: msg}
@@ -4268,10 +4266,6 @@ func errorFromCode(code uint32, msg string) error {
 	case 0xff_04_00_00:
 		return &internalClientError{msg: msg}
 	default:
-		return &unexpectedMessageError{
-			msg: fmt.Sprintf(
-				"invalid error code 0x%x with message %q", code, msg,
-			),
-		}
+		return nil
 	}
 }
@@ -54,6 +54,8 @@ func (c *protocolConnection) fallThrough(r *buff.Reader) error {
 					err)}
 			}
 			c.serverSettings.Set(name, i)
+		case "suggested_tls_security":
+			c.suggestedTLSSecurity = r.PopString()
 		case "system_config":
 			p := r.PopSlice(r.PopUint32())
 			d := p.PopSlice(p.PopUint32())
@@ -122,6 +124,8 @@ func (c *protocolConnection) fallThrough2pX(r *buff.Reader) error {
 					err)}
 			}
 			c.serverSettings.Set(name, i)
+		case "suggested_tls_security":
+			c.suggestedTLSSecurity = r.PopString()
 		case "system_config":
 			p := r.PopSlice(r.PopUint32())
 			d := p.PopSlice(p.PopUint32())
@@ -40,6 +40,11 @@ func (c *protocolConnection) execGranularFlow0pX(
 		return c.pesimistic0pX(r, q)
 	}
 
+	err := checkCardinality(q, ids.card)
+	if err != nil {
+		return err
+	}
+
 	cdcs, err := c.codecsFromIDs(ids, q)
 	if err != nil {
 		return err
@@ -195,11 +200,12 @@ func (c *protocolConnection) prepare0pX(r *buff.Reader, q *query) error {
 		switch Message(r.MsgType) {
 		case ParseComplete:
 			c.cacheCapabilities0pX(q, decodeHeaders0pX(r))
-			r.Discard(1) // cardinality
-			ids := idPair{in: r.PopUUID(), out: r.PopUUID()}
+			ids := idPair{card: Cardinality(r.PopUint8())}
+			ids.in = r.PopUUID()
+			ids.out = r.PopUUID()
 			c.cacheTypeIDs(q, ids)
 		case ReadyForCommand:
-			decodeReadyForCommandMsg(r)
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case ErrorResponse:
 			err = wrapAll(err, decodeErrorResponseMsg(r, q.cmd))
@@ -243,7 +249,7 @@ func (c *protocolConnection) describe(
 		case CommandDataDescription:
 			descs, _, err = c.decodeCommandDataDescriptionMsg0pX(r, q)
 		case ReadyForCommand:
-			decodeReadyForCommandMsg(r)
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case ErrorResponse:
 			err = wrapAll(err, decodeErrorResponseMsg(r, q.cmd))
@@ -293,7 +299,7 @@ func (c *protocolConnection) execute0pX(
 	for r.Next(done.Chan) {
 		switch Message(r.MsgType) {
 		case Data:
-			val, ok, e := decodeDataMsg(r, q, cdcs)
+			val, ok, e := c.decodeDataMsg(r, q, cdcs)
 			if e != nil {
 				if err == errZeroResults {
 					err = e
@@ -311,7 +317,7 @@ func (c *protocolConnection) execute0pX(
 		case CommandComplete:
 			decodeCommandCompleteMsg0pX(r)
 		case ReadyForCommand:
-			decodeReadyForCommandMsg(r)
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case ErrorResponse:
 			if err == errZeroResults {
@@ -377,7 +383,7 @@ func (c *protocolConnection) optimistic0pX(
 	for r.Next(done.Chan) {
 		switch Message(r.MsgType) {
 		case Data:
-			val, ok, e := decodeDataMsg(r, q, cdcs)
+			val, ok, e := c.decodeDataMsg(r, q, cdcs)
 			if e != nil {
 				if err == errZeroResults {
 					err = e
@@ -407,7 +413,7 @@ func (c *protocolConnection) optimistic0pX(
 
 			c.cacheCapabilities0pX(q, headers)
 		case ReadyForCommand:
-			decodeReadyForCommandMsg(r)
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case ErrorResponse:
 			if err == errZeroResults {
@@ -439,12 +445,12 @@ func decodeCommandCompleteMsg0pX(r *buff.Reader) {
 	r.PopBytes() // command status
 }
 
-func decodeReadyForCommandMsg(r *buff.Reader) {
+func (c *protocolConnection) decodeReadyForCommandMsg(r *buff.Reader) {
 	ignoreHeaders(r)
-	r.Discard(1) // transaction state
+	c.transactionState = r.PopUint8()
 }
 
-func decodeDataMsg(
+func (c *protocolConnection) decodeDataMsg(
 	r *buff.Reader,
 	q *query,
 	cdcs *codecPair,
@@ -456,24 +462,29 @@ func decodeDataMsg(
 	}
 	elmLen := r.PopUint32()
 
+	// reuse the connection's element reader instead of allocating
+	// a new one for every row.
+	c.elmReader.Reset(r.Buf[:elmLen])
+	r.Discard(int(elmLen))
+
 	if !q.flat() {
 		val := reflect.New(q.outType).Elem()
 		err := cdcs.out.Decode(
-			r.PopSlice(elmLen),
+			&c.elmReader,
 			unsafe.Pointer(val.UnsafeAddr()),
 		)
 		if err != nil {
-			return reflect.Value{}, false, err
+			return reflect.Value{}, false, wrapDecodeError(err)
 		}
 		return val, true, nil
 	}
 
 	err := cdcs.out.Decode(
-		r.PopSlice(elmLen),
+		&c.elmReader,
 		unsafe.Pointer(q.out.UnsafeAddr()),
 	)
 	if err != nil {
-		return reflect.Value{}, false, err
+		return reflect.Value{}, false, wrapDecodeError(err)
 	}
 
 	return reflect.Value{}, false, nil
@@ -529,5 +540,10 @@ func (c *protocolConnection) decodeCommandDataDescriptionMsg0pX(
 
 	descCache.Put(descs.In.ID, descs.In)
 	descCache.Put(descs.Out.ID, descs.Out)
+
+	if e := checkCardinality(q, descs.Card); e != nil {
+		return nil, nil, e
+	}
+
 	return &descs, headers, nil
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/edgedb/edgedb-go/internal/buff"
 	"github.com/edgedb/edgedb-go/internal/codecs"
 	"github.com/edgedb/edgedb-go/internal/descriptor"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
 	"github.com/edgedb/edgedb-go/internal/state"
 )
 
@@ -35,6 +36,11 @@ func (c *protocolConnection) execGranularFlow1pX(
 		return c.pesimistic1pX(r, q)
 	}
 
+	err := checkCardinality(q, ids.card)
+	if err != nil {
+		return err
+	}
+
 	cdcs, err := c.codecsFromIDs(ids, q)
 	if err != nil {
 		return err
@@ -65,7 +71,7 @@ func (c *protocolConnection) parse1pX(
 ) (*CommandDescription, error) {
 	w := buff.NewWriter(c.writeMemory[:0])
 	w.BeginMessage(uint8(Parse))
-	w.PushUint16(0) // no headers
+	writeHeaders1pX(w, q.annotations)
 	w.PushUint64(q.capabilities)
 	w.PushUint64(0) // no compilation_flags
 	w.PushUint64(0) // no implicit limit
@@ -102,7 +108,7 @@ func (c *protocolConnection) parse1pX(
 			desc, e = c.decodeCommandDataDescriptionMsg1pX(r, q)
 			err = wrapAll(err, e)
 		case ReadyForCommand:
-			decodeReadyForCommandMsg(r)
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case ErrorResponse:
 			err = wrapAll(err, decodeErrorResponseMsg(r, q.cmd))
@@ -171,9 +177,18 @@ func (c *protocolConnection) decodeCommandDataDescriptionMsg1pX(
 			q.expCard)}
 	}
 
-	c.cacheTypeIDs(q, idPair{in: descs.In.ID, out: descs.Out.ID})
+	c.cacheTypeIDs(q, idPair{
+		in:   descs.In.ID,
+		out:  descs.Out.ID,
+		card: descs.Card,
+	})
 	descCache.Put(descs.In.ID, descs.In)
 	descCache.Put(descs.Out.ID, descs.Out)
+
+	if e := checkCardinality(q, descs.Card); e != nil {
+		return nil, e
+	}
+
 	return &descs, nil
 }
 
@@ -184,7 +199,7 @@ func (c *protocolConnection) execute1pX(
 ) error {
 	w := buff.NewWriter(c.writeMemory[:0])
 	w.BeginMessage(uint8(Execute))
-	w.PushUint16(0) // no headers
+	writeHeaders1pX(w, q.annotations)
 	w.PushUint64(q.capabilities)
 	w.PushUint64(0) // no compilation_flags
 	w.PushUint64(0) // no implicit limit
@@ -231,7 +246,7 @@ func (c *protocolConnection) execute1pX(
 			cdcs, e = c.codecsFromDescriptors1pX(q, descs)
 			err = wrapAll(err, e)
 		case Data:
-			val, ok, e := decodeDataMsg(r, q, cdcs)
+			val, ok, e := c.decodeDataMsg(r, q, cdcs)
 			if e != nil {
 				if err == errZeroResults {
 					err = e
@@ -251,7 +266,7 @@ func (c *protocolConnection) execute1pX(
 				err = wrapAll(err, e)
 			}
 		case ReadyForCommand:
-			decodeReadyForCommandMsg(r)
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case ErrorResponse:
 			if err == errZeroResults {
@@ -326,12 +341,14 @@ func (c *protocolConnection) decodeCommandCompleteMsg1pX(
 	discardHeaders0pX(r)
 	c.cacheCapabilities1pX(q, r.PopUint64())
 	r.Discard(int(r.PopUint32())) // discard command status
-	if r.PopUUID() == descriptor.IDZero {
+	id := r.PopUUID()
+	if id == descriptor.IDZero {
 		// empty state data
 		r.Discard(4)
 		return nil
 	}
 
+	c.useCachedStateCodec(id)
 	r.Discard(int(r.PopUint32())) // state data
 	return nil
 }
@@ -342,10 +359,12 @@ func (c *protocolConnection) decodeStateDataDescription(r *buff.Reader) error {
 	}
 
 	id := r.PopUUID()
-	desc, err := descriptor.Pop(
-		r.PopSlice(r.PopUint32()),
-		c.protocolVersion,
-	)
+	data := r.PopSlice(r.PopUint32())
+	if c.useCachedStateCodec(id) {
+		return nil
+	}
+
+	desc, err := descriptor.Pop(data, c.protocolVersion)
 	if err != nil {
 		return &binaryProtocolError{err: fmt.Errorf(
 			"decoding ParameterStatus state_description: %w", err)}
@@ -361,6 +380,34 @@ func (c *protocolConnection) decodeStateDataDescription(r *buff.Reader) error {
 			err)}
 	}
 
+	stateCodecCache.Put(id, codec)
 	c.stateCodec = codec
 	return nil
 }
+
+// stateDescriptorID returns the id of the state type descriptor
+// that is sent to the server with every query.
+func (c *protocolConnection) stateDescriptorID() types.UUID {
+	if c.stateCodec == nil {
+		return descriptor.IDZero
+	}
+
+	return c.stateCodec.DescriptorID()
+}
+
+// useCachedStateCodec switches to the state codec for the state type
+// descriptor id. It returns false if the descriptor has not been seen yet and
+// must be decoded.
+func (c *protocolConnection) useCachedStateCodec(id types.UUID) bool {
+	if c.stateDescriptorID() == id {
+		return true
+	}
+
+	codec, ok := stateCodecCache.Get(id)
+	if !ok {
+		return false
+	}
+
+	c.stateCodec = codec.(codecs.Encoder)
+	return true
+}
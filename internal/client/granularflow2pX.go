@@ -30,6 +30,10 @@ func (c *protocolConnection) execGranularFlow2pX(
 	r *buff.Reader,
 	q *query,
 ) error {
+	if q.stmt != nil {
+		return c.execPrepared2pX(r, q)
+	}
+
 	var cdcs *codecPair
 	if q.parse {
 		ids, ok := c.getCachedTypeIDs(q)
@@ -37,7 +41,11 @@ func (c *protocolConnection) execGranularFlow2pX(
 			return c.pesimistic2pX(r, q)
 		}
 
-		var err error
+		err := checkCardinality(q, ids.card)
+		if err != nil {
+			return err
+		}
+
 		cdcs, err = c.codecsFromIDsV2(ids, q)
 		if err != nil {
 			return err
@@ -51,6 +59,34 @@ func (c *protocolConnection) execGranularFlow2pX(
 	return c.execute2pX(r, q, cdcs)
 }
 
+func (c *protocolConnection) execPrepared2pX(r *buff.Reader, q *query) error {
+	if !q.stmt.prepared {
+		desc, err := c.parse2pX(r, q)
+		if err != nil {
+			return err
+		}
+
+		in, err := codecs.BuildEncoderV2(&desc.In, c.protocolVersion)
+		if err != nil {
+			return &invalidArgumentError{msg: err.Error()}
+		}
+
+		q.stmt.describe(desc, in)
+		return nil
+	}
+
+	if err := checkCardinality(q, q.stmt.card); err != nil {
+		return err
+	}
+
+	cdcs, err := q.stmt.codecs(q)
+	if err != nil {
+		return err
+	}
+
+	return c.execute2pX(r, q, cdcs)
+}
+
 func (c *protocolConnection) pesimistic2pX(r *buff.Reader, q *query) error {
 	desc, err := c.parse2pX(r, q)
 	if err != nil {
@@ -71,7 +107,7 @@ func (c *protocolConnection) parse2pX(
 ) (*CommandDescriptionV2, error) {
 	w := buff.NewWriter(c.writeMemory[:0])
 	w.BeginMessage(uint8(Parse))
-	w.PushUint16(0) // no headers
+	writeHeaders1pX(w, q.annotations)
 	w.PushUint64(q.capabilities)
 	w.PushUint64(0) // no compilation_flags
 	w.PushUint64(0) // no implicit limit
@@ -111,7 +147,7 @@ func (c *protocolConnection) parse2pX(
 			desc, e = c.decodeCommandDataDescriptionMsg2pX(r, q)
 			err = wrapAll(err, e)
 		case ReadyForCommand:
-			decodeReadyForCommandMsg(r)
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case ErrorResponse:
 			err = wrapAll(err, decodeErrorResponseMsg(r, q.cmd))
@@ -180,9 +216,18 @@ func (c *protocolConnection) decodeCommandDataDescriptionMsg2pX(
 			q.expCard)}
 	}
 
-	c.cacheTypeIDs(q, idPair{in: descs.In.ID, out: descs.Out.ID})
+	c.cacheTypeIDs(q, idPair{
+		in:   descs.In.ID,
+		out:  descs.Out.ID,
+		card: descs.Card,
+	})
 	descCache.Put(descs.In.ID, descs.In)
 	descCache.Put(descs.Out.ID, descs.Out)
+
+	if e := checkCardinality(q, descs.Card); e != nil {
+		return nil, e
+	}
+
 	return &descs, nil
 }
 
@@ -193,7 +238,7 @@ func (c *protocolConnection) execute2pX(
 ) error {
 	w := buff.NewWriter(c.writeMemory[:0])
 	w.BeginMessage(uint8(Execute))
-	w.PushUint16(0) // no headers
+	writeHeaders1pX(w, q.annotations)
 	w.PushUint64(q.capabilities)
 	w.PushUint64(0) // no compilation_flags
 	w.PushUint64(0) // no implicit limit
@@ -219,8 +264,24 @@ func (c *protocolConnection) execute2pX(
 
 	w.BeginMessage(uint8(Sync))
 	w.EndMessage()
+	msgs := w.Unwrap()
+
+	// The Execute message holds the query, its arguments and the state
+	// so the messages are used as the result cache key.
+	var (
+		cacheKey string
+		cached   [][]byte
+		readOnly bool
+	)
+	useCache := c.useResultCache(q)
+	if useCache {
+		cacheKey = string(msgs)
+		if data, ok := c.resultCache.get(cacheKey); ok {
+			return c.decodeCachedResult(q, cdcs, data)
+		}
+	}
 
-	if e := c.soc.WriteAll(w.Unwrap()); e != nil {
+	if e := c.soc.WriteAll(msgs); e != nil {
 		return &clientConnectionClosedError{err: e}
 	}
 
@@ -241,8 +302,15 @@ func (c *protocolConnection) execute2pX(
 			err = wrapAll(err, e)
 			cdcs, e = c.codecsFromDescriptors2pX(q, descs)
 			err = wrapAll(err, e)
+
+			// the result doesn't match the descriptors in cacheKey
+			useCache = false
 		case Data:
-			val, ok, e := decodeDataMsg(r, q, cdcs)
+			if useCache {
+				cached = append(cached, append([]byte(nil), r.Buf...))
+			}
+
+			val, ok, e := c.decodeDataMsg(r, q, cdcs)
 			if e != nil {
 				if err == errZeroResults {
 					err = e
@@ -258,11 +326,13 @@ func (c *protocolConnection) execute2pX(
 				err = nil
 			}
 		case CommandComplete:
-			if e := c.decodeCommandCompleteMsg2pX(q, r); e != nil {
+			capabilities, e := c.decodeCommandCompleteMsg2pX(q, r)
+			if e != nil {
 				err = wrapAll(err, e)
 			}
+			readOnly = capabilities == 0
 		case ReadyForCommand:
-			decodeReadyForCommandMsg(r)
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case ErrorResponse:
 			if err == errZeroResults {
@@ -286,9 +356,51 @@ func (c *protocolConnection) execute2pX(
 		q.out.Set(tmp)
 	}
 
+	if useCache && readOnly && (err == nil || err == errZeroResults) {
+		c.resultCache.put(cacheKey, cached)
+	}
+
 	return err
 }
 
+// useResultCache returns true if the result of q
+// can be read from and written to the result cache.
+func (c *protocolConnection) useResultCache(q *query) bool {
+	return c.resultCache != nil &&
+		q.fmt != Null &&
+		c.transactionState == notInTransaction
+}
+
+// decodeCachedResult decodes the Data messages of a cached result
+// the same way that execute2pX decodes them.
+func (c *protocolConnection) decodeCachedResult(
+	q *query,
+	cdcs *codecPair,
+	data [][]byte,
+) error {
+	if q.expCard == AtMostOne && len(data) == 0 {
+		return errZeroResults
+	}
+
+	tmp := q.out
+	for _, msg := range data {
+		val, ok, err := c.decodeDataMsg(buff.SimpleReader(msg), q, cdcs)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			tmp = reflect.Append(tmp, val)
+		}
+	}
+
+	if !q.flat() {
+		q.out.Set(tmp)
+	}
+
+	return nil
+}
+
 func (c *protocolConnection) codecsFromIDsV2(
 	ids *idPair,
 	q *query,
@@ -369,31 +481,38 @@ func (c *protocolConnection) codecsFromDescriptors2pX(
 	return &cdcs, nil
 }
 
+// decodeCommandCompleteMsg2pX returns the capabilities
+// that the command actually used.
 func (c *protocolConnection) decodeCommandCompleteMsg2pX(
 	q *query,
 	r *buff.Reader,
-) error {
+) (uint64, error) {
 	discardHeaders0pX(r)
-	c.cacheCapabilities1pX(q, r.PopUint64())
+	capabilities := r.PopUint64()
+	c.cacheCapabilities1pX(q, capabilities)
 	r.Discard(int(r.PopUint32())) // discard command status
-	if r.PopUUID() == descriptor.IDZero {
+	id := r.PopUUID()
+	if id == descriptor.IDZero {
 		// empty state data
 		r.Discard(4)
-		return nil
+		return capabilities, nil
 	}
 
+	c.useCachedStateCodec(id)
 	r.Discard(int(r.PopUint32())) // state data
-	return nil
+	return capabilities, nil
 }
 
 func (c *protocolConnection) decodeStateDataDescription2pX(
 	r *buff.Reader,
 ) error {
 	id := r.PopUUID()
-	desc, err := descriptor.PopV2(
-		r.PopSlice(r.PopUint32()),
-		c.protocolVersion,
-	)
+	data := r.PopSlice(r.PopUint32())
+	if c.useCachedStateCodec(id) {
+		return nil
+	}
+
+	desc, err := descriptor.PopV2(data, c.protocolVersion)
 	if err != nil {
 		return &binaryProtocolError{err: fmt.Errorf(
 			"decoding ParameterStatus state_description: %w", err)}
@@ -409,6 +528,7 @@ func (c *protocolConnection) decodeStateDataDescription2pX(
 			err)}
 	}
 
+	stateCodecCache.Put(id, codec)
 	c.stateCodec = codec
 	return nil
 }
@@ -0,0 +1,538 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edgedb
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/codecs"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+	"github.com/stretchr/testify/require"
+	"github.com/xdg/scram"
+)
+
+// mockMessage is a message received by the mockServer from a client.
+type mockMessage struct {
+	Type Message
+	Body []byte
+}
+
+// mockResult is the response a mockServer sends for a query.
+type mockResult struct {
+	Card   Cardinality
+	InID   types.UUID
+	InDsc  []byte // encoded input type descriptors
+	OutID  types.UUID
+	OutDsc []byte        // encoded output type descriptors
+	Rows   [][]byte      // encoded Data elements
+	ErrMsg string        // if set an ErrorResponse is sent instead
+	Delay  time.Duration // time to wait before responding to Execute
+
+	// ExecErrCode if set makes Execute fail with this error code.
+	ExecErrCode uint32
+
+	// Status is the CommandComplete status, defaults to "SELECT <n>".
+	Status string
+
+	// TxState is the transaction state sent in ReadyForCommand,
+	// defaults to 'I' (not in a transaction).
+	TxState uint8
+
+	// Capabilities are the capabilities that the command uses.
+	Capabilities uint64
+}
+
+// mockServer is a minimal EdgeDB protocol 3.0 server that speaks the binary
+// protocol over plaintext TCP. It is used to test client behaviour that is
+// hard to provoke from a real server.
+type mockServer struct {
+	t        *testing.T
+	ln       net.Listener
+	user     string
+	password string // SCRAM authentication is required if set.
+
+	// handshakeDelay is how long the server waits before
+	// responding to a ClientHandshake.
+	handshakeDelay time.Duration
+
+	// parameters are sent as ParameterStatus messages during the handshake.
+	parameters map[string]string
+
+	// extensions are advertised in a ServerHandshake if not nil.
+	extensions []string
+
+	mu       sync.Mutex
+	messages []mockMessage
+	results  map[string]mockResult
+
+	// handshakes is the number of handshakes in progress
+	// and maxHandshakes is the most that were ever in progress at once.
+	handshakes    int
+	maxHandshakes int
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &mockServer{
+		t:       t,
+		ln:      ln,
+		user:    "edgedb",
+		results: map[string]mockResult{},
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go s.serve()
+	return s
+}
+
+// Options returns client options for connecting to the server.
+func (s *mockServer) Options() Options {
+	addr := s.ln.Addr().(*net.TCPAddr)
+	o := Options{
+		Host:     addr.IP.String(),
+		Port:     addr.Port,
+		User:     s.user,
+		Database: "edgedb",
+	}
+	if s.password != "" {
+		o.Password = types.NewOptionalStr(s.password)
+	}
+	return o.WithPlaintext()
+}
+
+// SetResult sets the response for cmd.
+func (s *mockServer) SetResult(cmd string, result mockResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[cmd] = result
+}
+
+// MaxHandshakes returns the most handshakes that were in progress at once.
+func (s *mockServer) MaxHandshakes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxHandshakes
+}
+
+// Messages returns the messages of type typ received so far.
+func (s *mockServer) Messages(typ Message) []mockMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var msgs []mockMessage
+	for _, m := range s.messages {
+		if m.Type == typ {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs
+}
+
+// Commands returns the commands of the Execute messages received so far.
+func (s *mockServer) Commands() []string {
+	msgs := s.Messages(Execute)
+	cmds := make([]string, len(msgs))
+	for i, msg := range msgs {
+		_, _, cmds[i] = popQuery(msg.Body)
+	}
+	return cmds
+}
+
+func (s *mockServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *mockServer) read(conn net.Conn) (Message, []byte, error) {
+	head := make([]byte, 5)
+	if _, err := io.ReadFull(conn, head); err != nil {
+		return 0, nil, err
+	}
+	body := make([]byte, binary.BigEndian.Uint32(head[1:])-4)
+	if _, err := io.ReadFull(conn, body); err != nil {
+		return 0, nil, err
+	}
+
+	msg := mockMessage{Type: Message(head[0]), Body: body}
+	s.mu.Lock()
+	s.messages = append(s.messages, msg)
+	s.mu.Unlock()
+	return msg.Type, body, nil
+}
+
+func (s *mockServer) handle(conn net.Conn) {
+	defer conn.Close() // nolint:errcheck
+
+	if _, _, err := s.read(conn); err != nil { // ClientHandshake
+		return
+	}
+
+	s.mu.Lock()
+	s.handshakes++
+	s.maxHandshakes = max(s.maxHandshakes, s.handshakes)
+	s.mu.Unlock()
+	time.Sleep(s.handshakeDelay)
+	s.mu.Lock()
+	s.handshakes--
+	s.mu.Unlock()
+
+	if s.extensions != nil {
+		w := buff.NewWriter(nil)
+		w.BeginMessage(uint8(ServerHandshake))
+		w.PushUint16(3) // major version
+		w.PushUint16(0) // minor version
+		w.PushUint16(uint16(len(s.extensions)))
+		for _, name := range s.extensions {
+			w.PushString(name)
+			w.PushUint16(0) // annotation count
+		}
+		w.EndMessage()
+
+		if _, err := conn.Write(w.Unwrap()); err != nil {
+			return
+		}
+	}
+
+	w := buff.NewWriter(nil)
+	if s.password != "" {
+		if !s.authenticate(conn) {
+			return
+		}
+	} else {
+		w.BeginMessage(uint8(Authentication))
+		w.PushUint32(0) // AuthenticationOK
+		w.EndMessage()
+	}
+
+	w.BeginMessage(uint8(ServerKeyData))
+	w.PushBytes(make([]byte, 32))
+	w.EndMessage()
+
+	// state descriptor: an input shape without any fields
+	stateID := types.UUID{1}
+	w.BeginMessage(uint8(StateDataDescription))
+	w.PushUUID(stateID)
+	w.BeginBytes()
+	w.BeginBytes()
+	w.PushUint8(uint8(descriptor.InputShape))
+	w.PushUUID(stateID)
+	w.PushUint16(0) // field count
+	w.EndBytes()
+	w.EndBytes()
+	w.EndMessage()
+
+	for name, val := range s.parameters {
+		w.BeginMessage(uint8(ParameterStatus))
+		w.PushString(name)
+		w.PushString(val)
+		w.EndMessage()
+	}
+	pushReadyForCommand(w)
+
+	if _, err := conn.Write(w.Unwrap()); err != nil {
+		return
+	}
+
+	for {
+		typ, body, err := s.read(conn)
+		if err != nil {
+			return
+		}
+
+		w = buff.NewWriter(nil)
+		switch typ {
+		case Parse:
+			s.respondParse(w, body)
+		case Execute:
+			s.respondExecute(w, body)
+		case Terminate:
+			return
+		default:
+			continue
+		}
+
+		if _, err := conn.Write(w.Unwrap()); err != nil {
+			return
+		}
+	}
+}
+
+func (s *mockServer) authenticate(conn net.Conn) bool {
+	client, err := scram.SHA256.NewClient(s.user, s.password, "")
+	require.NoError(s.t, err)
+	creds := client.GetStoredCredentials(scram.KeyFactors{
+		Salt:  "mock-salt",
+		Iters: 4096,
+	})
+	server, err := scram.SHA256.NewServer(
+		func(string) (scram.StoredCredentials, error) { return creds, nil })
+	require.NoError(s.t, err)
+	conv := server.NewConversation()
+
+	w := buff.NewWriter(nil)
+	w.BeginMessage(uint8(Authentication))
+	w.PushUint32(0xa) // AuthenticationRequiredSASLMessage
+	w.PushUint32(1)
+	w.PushString("SCRAM-SHA-256")
+	w.EndMessage()
+	if _, e := conn.Write(w.Unwrap()); e != nil {
+		return false
+	}
+
+	// AuthenticationSASLInitialResponse
+	_, body, err := s.read(conn)
+	if err != nil {
+		return false
+	}
+	r := buff.SimpleReader(body)
+	r.PopString() // method
+	msg, err := conv.Step(r.PopString())
+	if err != nil {
+		return false
+	}
+
+	w = buff.NewWriter(nil)
+	w.BeginMessage(uint8(Authentication))
+	w.PushUint32(0xb) // AuthenticationSASLContinue
+	w.PushString(msg)
+	w.EndMessage()
+	if _, e := conn.Write(w.Unwrap()); e != nil {
+		return false
+	}
+
+	// AuthenticationSASLResponse
+	_, body, err = s.read(conn)
+	if err != nil {
+		return false
+	}
+	msg, err = conv.Step(buff.SimpleReader(body).PopString())
+	if err != nil || !conv.Valid() {
+		w = buff.NewWriter(nil)
+		pushErrorResponse(w, 0x07_01_00_00, "authentication failed")
+		_, _ = conn.Write(w.Unwrap())
+		return false
+	}
+
+	w = buff.NewWriter(nil)
+	w.BeginMessage(uint8(Authentication))
+	w.PushUint32(0xc) // AuthenticationSASLFinal
+	w.PushString(msg)
+	w.EndMessage()
+	w.BeginMessage(uint8(Authentication))
+	w.PushUint32(0) // AuthenticationOK
+	w.EndMessage()
+	_, err = conn.Write(w.Unwrap())
+	return err == nil
+}
+
+// popQuery reads the common prefix of Parse and Execute messages.
+func popQuery(body []byte) (*buff.Reader, Format, string) {
+	r := buff.SimpleReader(body)
+	n := int(r.PopUint16())
+	for i := 0; i < n; i++ {
+		r.PopString() // annotation name
+		r.PopString() // annotation value
+	}
+	r.Discard(8 + 8 + 8) // capabilities, compilation_flags, implicit_limit
+	r.Discard(1)         // language
+	frmt := Format(r.PopUint8())
+	r.Discard(1) // expected_cardinality
+	cmd := r.PopString()
+	return r, frmt, cmd
+}
+
+func (s *mockServer) result(frmt Format, cmd string) mockResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result, ok := s.results[cmd]
+	if !ok {
+		result.Card = NoResult
+	}
+	if result.TxState == 0 {
+		result.TxState = 'I'
+	}
+	if frmt == Null {
+		result.OutID = descriptor.IDZero
+		result.OutDsc = nil
+		result.Rows = nil
+	}
+	return result
+}
+
+func (s *mockServer) respondParse(w *buff.Writer, body []byte) {
+	_, frmt, cmd := popQuery(body)
+	result := s.result(frmt, cmd)
+	if result.ErrMsg != "" {
+		pushErrorResponse(w, 0x04_00_00_00, result.ErrMsg)
+		pushReadyForCommandState(w, result.TxState)
+		return
+	}
+
+	w.BeginMessage(uint8(CommandDataDescription))
+	w.PushUint16(0) // no annotations
+	w.PushUint64(result.Capabilities)
+	w.PushUint8(uint8(result.Card))
+	w.PushUUID(result.InID)
+	w.PushUint32(uint32(len(result.InDsc)))
+	w.PushBytes(result.InDsc)
+	w.PushUUID(result.OutID)
+	w.PushUint32(uint32(len(result.OutDsc)))
+	w.PushBytes(result.OutDsc)
+	w.EndMessage()
+	pushReadyForCommandState(w, result.TxState)
+}
+
+func (s *mockServer) respondExecute(w *buff.Writer, body []byte) {
+	_, frmt, cmd := popQuery(body)
+	result := s.result(frmt, cmd)
+	time.Sleep(result.Delay)
+	if result.ErrMsg != "" {
+		pushErrorResponse(w, 0x04_00_00_00, result.ErrMsg)
+		pushReadyForCommandState(w, result.TxState)
+		return
+	}
+
+	if result.ExecErrCode != 0 {
+		pushErrorResponse(w, result.ExecErrCode, "mock execute error")
+		pushReadyForCommandState(w, result.TxState)
+		return
+	}
+
+	for _, row := range result.Rows {
+		w.BeginMessage(uint8(Data))
+		w.PushUint16(1)
+		w.PushUint32(uint32(len(row)))
+		w.PushBytes(row)
+		w.EndMessage()
+	}
+
+	w.BeginMessage(uint8(CommandComplete))
+	w.PushUint16(0) // no annotations
+	w.PushUint64(result.Capabilities)
+	status := result.Status
+	if status == "" {
+		status = "SELECT " + strconv.Itoa(len(result.Rows))
+	}
+	w.PushString(status)
+	w.PushUUID(descriptor.IDZero) // no state
+	w.PushUint32(0)
+	w.EndMessage()
+	pushReadyForCommandState(w, result.TxState)
+}
+
+func pushReadyForCommand(w *buff.Writer) {
+	pushReadyForCommandState(w, 'I')
+}
+
+func pushReadyForCommandState(w *buff.Writer, state uint8) {
+	w.BeginMessage(uint8(ReadyForCommand))
+	w.PushUint16(0) // no annotations
+	w.PushUint8(state)
+	w.EndMessage()
+}
+
+func pushErrorResponse(w *buff.Writer, code uint32, msg string) {
+	w.BeginMessage(uint8(ErrorResponse))
+	w.PushUint8(120) // severity: error
+	w.PushUint32(code)
+	w.PushString(msg)
+	w.PushUint16(0) // no attributes
+	w.EndMessage()
+}
+
+// mockStrResult returns a result of type str containing vals.
+func mockStrResult(vals ...string) mockResult {
+	name := "std::str"
+	dsc := make([]byte, 4+1+16+4, 4+1+16+4+len(name)+1+2)
+	binary.BigEndian.PutUint32(dsc, uint32(cap(dsc)-4))
+	dsc[4] = uint8(descriptor.Scalar)
+	copy(dsc[5:], codecs.StrID[:])
+	binary.BigEndian.PutUint32(dsc[21:], uint32(len(name)))
+	dsc = append(dsc, name...)
+	dsc = append(dsc, 1)    // schema_defined
+	dsc = append(dsc, 0, 0) // no ancestors
+
+	rows := make([][]byte, len(vals))
+	for i, v := range vals {
+		rows[i] = []byte(v)
+	}
+
+	card := Many
+	if len(vals) == 1 {
+		card = One
+	}
+
+	return mockResult{
+		Card:   card,
+		OutID:  codecs.StrID,
+		OutDsc: dsc,
+		Rows:   rows,
+	}
+}
+
+// mockInt64Args returns input descriptors for n positional int64 arguments.
+func mockInt64Args(result mockResult, n int) mockResult {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = strconv.Itoa(i)
+	}
+
+	return mockInt64Kwargs(result, names...)
+}
+
+// mockInt64Kwargs returns input descriptors for named int64 arguments.
+func mockInt64Kwargs(result mockResult, names ...string) mockResult {
+	name := "std::int64"
+	scalar := make([]byte, 4+1+16+4, 4+1+16+4+len(name)+1+2)
+	binary.BigEndian.PutUint32(scalar, uint32(cap(scalar)-4))
+	scalar[4] = uint8(descriptor.Scalar)
+	copy(scalar[5:], codecs.Int64ID[:])
+	binary.BigEndian.PutUint32(scalar[21:], uint32(len(name)))
+	scalar = append(scalar, name...)
+	scalar = append(scalar, 1)    // schema_defined
+	scalar = append(scalar, 0, 0) // no ancestors
+
+	id := types.UUID{0xa2, 0x95}
+	obj := []byte{0, 0, 0, 0, uint8(descriptor.Object)}
+	obj = append(obj, id[:]...)
+	obj = append(obj, 0, 0, 0) // schema_defined, type
+	obj = append(obj, 0, uint8(len(names)))
+	for _, name := range names {
+		obj = append(obj, 0, 0, 0, 0, 'A') // flags, cardinality
+		obj = append(obj, 0, 0, 0, uint8(len(name)))
+		obj = append(obj, name...)
+		obj = append(obj, 0, 0, 0, 0) // type, source_type
+	}
+	binary.BigEndian.PutUint32(obj, uint32(len(obj)-4))
+
+	result.InID = id
+	result.InDsc = append(scalar, obj...)
+	return result
+}
@@ -21,6 +21,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/edgedb/edgedb-go/internal"
 	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
 )
 
@@ -102,6 +103,11 @@ type Options struct {
 	// Has no effect for single connections.
 	Concurrency uint
 
+	// MaxConcurrentDials is the maximum number of connections the client
+	// establishes at the same time. Other connection attempts wait for an
+	// attempt in progress to finish. If MaxConcurrentDials is zero, 4 is used.
+	MaxConcurrentDials uint
+
 	// Parameters used to configure TLS connections to EdgeDB server.
 	TLSOptions TLSOptions
 
@@ -120,8 +126,90 @@ type Options struct {
 	SecretKey string
 
 	// WarningHandler is invoked when EdgeDB returns warnings. Defaults to
-	// edgedb.LogWarnings.
+	// edgedb.LogWarnings. It is also invoked when connecting if the server
+	// suggests a stricter tls_security than the default one in use.
 	WarningHandler WarningHandler
+
+	// AuthHandler is invoked with the SCRAM parameters chosen by the server
+	// each time a connection successfully authenticates. It can be used to
+	// audit authentication. The password and derived keys are never passed
+	// to AuthHandler.
+	AuthHandler func(AuthParameters)
+
+	// QueryPreprocessor, if set, transforms the text of every query before
+	// it is sent to the server, e.g. to add a comment used for routing.
+	// It is called once per query, the statements that the client uses to
+	// manage transactions are not passed to it.
+	QueryPreprocessor func(query string) string
+
+	// plaintext disables TLS entirely. See Options.WithPlaintext().
+	plaintext bool
+
+	// protocolVersion is the only protocol version used if it is not zero.
+	// See Options.WithProtocolVersion().
+	protocolVersion internal.ProtocolVersion
+
+	// disableStatementCache makes every query parsed by the server.
+	// See Options.WithoutStatementCache().
+	disableStatementCache bool
+
+	// resultCacheSize and resultCacheTTL configure the result cache.
+	// See Options.WithResultCache().
+	resultCacheSize int
+	resultCacheTTL  time.Duration
+}
+
+// WithResultCache returns a copy of the options that caches the results of
+// read only queries for ttl. Up to size results are cached, the least
+// recently used ones are evicted first. Results are keyed by the query text,
+// its arguments and the client's state, a cached result is decoded again
+// for every query that uses it.
+//
+// Results are only cached for queries that the server reports as read only
+// and never for queries in a transaction. Writes do not invalidate the
+// cache, so queries may return stale results for up to ttl. The result
+// cache is disabled by default.
+//
+// The result cache is only used with servers that speak protocol version
+// 2.0 or greater. It does nothing with older servers, every query is sent
+// to the server.
+func (o Options) WithResultCache( // nolint:gocritic
+	size int,
+	ttl time.Duration,
+) Options {
+	o.resultCacheSize = size
+	o.resultCacheTTL = ttl
+	return o
+}
+
+// WithoutStatementCache returns a copy of the options that does not cache
+// the type descriptors and codecs of queries. Every query is parsed by the
+// server and its codecs are built from the descriptors that the server
+// returns. This is slower and only useful to debug codec issues, e.g. to rule
+// out stale descriptors.
+func (o Options) WithoutStatementCache() Options { // nolint:gocritic
+	o.disableStatementCache = true
+	return o
+}
+
+// WithProtocolVersion returns a copy of the options that only connects with
+// protocol version major.minor. The handshake proposes exactly that version
+// and the connection fails if the server does not accept it. This is useful
+// for testing compatibility with older servers.
+func (o Options) WithProtocolVersion( // nolint:gocritic
+	major, minor uint16,
+) Options {
+	o.protocolVersion = internal.ProtocolVersion{Major: major, Minor: minor}
+	return o
+}
+
+// WithPlaintext returns a copy of the options that connects to the server
+// over raw TCP without a TLS layer. This is only useful for development
+// servers that have TLS disabled. Unlike TLSModeInsecure, which still uses
+// TLS but skips certificate verification, no encryption is used at all.
+func (o Options) WithPlaintext() Options { // nolint:gocritic
+	o.plaintext = true
+	return o
 }
 
 // TLSOptions contains the parameters needed to configure TLS on EdgeDB
@@ -154,6 +242,7 @@ const (
 
 // RetryBackoff returns the duration to wait after the nth attempt
 // before making the next attempt when retrying a transaction.
+// The wait is interrupted if the query's context is done.
 type RetryBackoff func(n int) time.Duration
 
 func defaultBackoff(attempt int) time.Duration {
@@ -291,16 +380,27 @@ func (o RetryOptions) ruleForException(err Error) (RetryRule, error) {
 type IsolationLevel string
 
 const (
-	// Serializable is the only isolation level
+	// Serializable is the default isolation level
 	Serializable IsolationLevel = "serializable"
+
+	// RepeatableRead runs transactions with REPEATABLE READ isolation.
+	// It requires EdgeDB 6.0 or later.
+	RepeatableRead IsolationLevel = "repeatable_read"
 )
 
+func checkIsolation(i IsolationLevel) {
+	switch i {
+	case Serializable, RepeatableRead:
+	default:
+		panic(fmt.Sprintf("unknown isolation level: %q", i))
+	}
+}
+
 // NewTxOptions returns the default TxOptions value.
+// The isolation level is the client's default unless it is set with
+// TxOptions.WithIsolation().
 func NewTxOptions() TxOptions {
-	return TxOptions{
-		fromFactory: true,
-		isolation:   Serializable,
-	}
+	return TxOptions{fromFactory: true}
 }
 
 // TxOptions configures how transactions behave.
@@ -313,16 +413,15 @@ type TxOptions struct {
 
 	readOnly   bool
 	deferrable bool
-	isolation  IsolationLevel
+
+	// isolation overrides the client's default isolation level if set.
+	isolation IsolationLevel
 }
 
 // WithIsolation returns a copy of the TxOptions
 // with the isolation level set to i.
 func (o TxOptions) WithIsolation(i IsolationLevel) TxOptions {
-	if i != Serializable {
-		panic(fmt.Sprintf("unknown isolation level: %q", i))
-	}
-
+	checkIsolation(i)
 	o.isolation = i
 	return o
 }
@@ -347,6 +446,8 @@ func (o TxOptions) startTxQuery() string { // nolint:gocritic
 	switch o.isolation {
 	case Serializable:
 		query += " ISOLATION SERIALIZABLE"
+	case RepeatableRead:
+		query += " ISOLATION REPEATABLE READ"
 	default:
 		panic(fmt.Sprintf("unknown isolation level: %q", o.isolation))
 	}
@@ -378,6 +479,18 @@ func (p Client) WithTxOptions(opts TxOptions) *Client { // nolint:gocritic
 	return &p
 }
 
+// WithTransactionIsolation returns a shallow copy of the client
+// with the default transaction isolation level set to i.
+// The default applies to every Tx() call unless the client's TxOptions
+// set an isolation level with TxOptions.WithIsolation().
+func (p Client) WithTransactionIsolation( // nolint:gocritic
+	i IsolationLevel,
+) *Client {
+	checkIsolation(i)
+	p.isolation = i
+	return &p
+}
+
 // WithRetryOptions returns a shallow copy of the client
 // with the RetryOptions set to opts.
 func (p Client) WithRetryOptions( // nolint:gocritic
@@ -531,3 +644,19 @@ func (p Client) WithWarningHandler( // nolint:gocritic
 	p.warningHandler = warningHandler
 	return &p
 }
+
+// WithAnnotation sets an annotation for the returned client. Annotations are
+// sent to the server as headers with every query. Use WithQueryAnnotations()
+// to set annotations for a single call.
+func (p Client) WithAnnotation( // nolint:gocritic
+	key, value string,
+) *Client {
+	annotations := make(map[string]string, len(p.annotations)+1)
+	for k, v := range p.annotations {
+		annotations[k] = v
+	}
+
+	annotations[key] = value
+	p.annotations = annotations
+	return &p
+}
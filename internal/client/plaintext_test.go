@@ -0,0 +1,79 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edgedb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaintextConnection(t *testing.T) {
+	server := newMockServer(t)
+	server.SetResult("SELECT 'It worked!';", mockStrResult("It worked!"))
+
+	ctx := context.Background()
+	p, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	var result string
+	err = p.QuerySingle(ctx, "SELECT 'It worked!';", &result)
+	require.NoError(t, err)
+	assert.Equal(t, "It worked!", result)
+}
+
+func TestPlaintextConnectionSCRAM(t *testing.T) {
+	server := newMockServer(t)
+	server.password = "secret"
+	server.SetResult("SELECT 'It worked!';", mockStrResult("It worked!"))
+
+	ctx := context.Background()
+	p, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	var result string
+	err = p.QuerySingle(ctx, "SELECT 'It worked!';", &result)
+	require.NoError(t, err)
+	assert.Equal(t, "It worked!", result)
+	assert.Len(t, server.Messages(AuthenticationSASLResponse), 1)
+
+	opts := server.Options()
+	opts.Password.Set("wrong")
+	p2, err := CreateClient(ctx, opts)
+	require.NoError(t, err)
+	defer p2.Close() // nolint:errcheck
+
+	err = p2.QuerySingle(ctx, "SELECT 'It worked!';", &result)
+	var edbErr Error
+	require.ErrorAs(t, err, &edbErr)
+	assert.True(t, edbErr.Category(AuthenticationError), err)
+}
+
+func TestPlaintextStrictSecurity(t *testing.T) {
+	t.Setenv("EDGEDB_CLIENT_SECURITY", "strict")
+	_, err := CreateClient(context.Background(), Options{
+		Host: "localhost",
+		Port: 5656,
+	}.WithPlaintext())
+	assert.EqualError(t, err, "edgedb.ConfigurationError: "+
+		"EDGEDB_CLIENT_SECURITY=strict "+
+		"but a plaintext connection was requested")
+}
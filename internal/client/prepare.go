@@ -0,0 +1,210 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edgedb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/edgedb/edgedb-go/internal/codecs"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+)
+
+// PrepareOptions configures how Client.Prepare() prepares a query.
+type PrepareOptions struct {
+	// SQL prepares the query as SQL instead of EdgeQL.
+	// This requires EdgeDB 6.0 or later.
+	SQL bool
+}
+
+// PreparedStatement is a query that has been parsed by the server.
+// Running a PreparedStatement reuses the descriptors and codecs
+// from Client.Prepare() so the query is not parsed again.
+// A PreparedStatement is safe for concurrent use
+// and runs on any of the client's connections.
+type PreparedStatement struct {
+	client *Client
+	lang   Language
+	cmd    string
+
+	card    Cardinality
+	in      codecs.Encoder
+	outDesc descriptor.V2
+
+	// prepared is false until the server has described the query.
+	prepared bool
+
+	mu       *sync.Mutex
+	decoders map[reflect.Type]codecs.Decoder
+}
+
+// Prepare parses a query without running it. The returned
+// PreparedStatement runs the query with the client's current options.
+// Prepared statements require EdgeDB 5.0 or later.
+func (p *Client) Prepare(
+	ctx context.Context,
+	cmd string,
+	opts PrepareOptions,
+) (*PreparedStatement, error) {
+	stmt := &PreparedStatement{
+		client:   p,
+		lang:     EdgeQL,
+		cmd:      cmd,
+		mu:       &sync.Mutex{},
+		decoders: make(map[reflect.Type]codecs.Decoder),
+	}
+
+	if opts.SQL {
+		stmt.lang = SQL
+	}
+
+	conn, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &query{
+		method:         "Prepare",
+		lang:           stmt.lang,
+		cmd:            cmd,
+		fmt:            Binary,
+		expCard:        Many,
+		capabilities:   conn.capabilities1pX(),
+		state:          p.state,
+		annotations:    mergeAnnotations(ctx, p.annotations),
+		parse:          true,
+		warningHandler: p.warningHandler,
+		stmt:           stmt,
+	}
+
+	err = conn.granularFlow(ctx, q)
+	if err = firstError(err, p.release(conn, err)); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// Query runs the prepared statement and returns the results.
+func (s *PreparedStatement) Query(
+	ctx context.Context,
+	out interface{},
+	args ...interface{},
+) error {
+	method := "Query"
+	if s.lang == SQL {
+		method = "QuerySQL"
+	}
+
+	conn, err := s.client.acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	q, err := newQuery(
+		method,
+		s.cmd,
+		args,
+		conn.capabilities1pX(),
+		s.client.state,
+		mergeAnnotations(ctx, s.client.annotations),
+		out,
+		true,
+		s.client.warningHandler,
+	)
+	if err == nil {
+		q.stmt = s
+		q.assertCard = expectedCardinality(ctx)
+		err = conn.granularFlow(ctx, q)
+	}
+
+	return firstError(err, s.client.release(conn, err))
+}
+
+// Execute runs the prepared statement without returning results.
+func (s *PreparedStatement) Execute(
+	ctx context.Context,
+	args ...interface{},
+) error {
+	method := "Execute"
+	if s.lang == SQL {
+		method = "ExecuteSQL"
+	}
+
+	conn, err := s.client.acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	q, err := newQuery(
+		method,
+		s.cmd,
+		args,
+		conn.capabilities1pX(),
+		copyState(s.client.state),
+		mergeAnnotations(ctx, s.client.annotations),
+		nil,
+		true,
+		s.client.warningHandler,
+	)
+	if err == nil {
+		q.stmt = s
+		q.assertCard = expectedCardinality(ctx)
+		err = conn.granularFlow(ctx, q)
+	}
+
+	return firstError(err, s.client.release(conn, err))
+}
+
+// describe stores the descriptors returned when the statement was parsed.
+// It is only called by Client.Prepare() before the statement is shared.
+func (s *PreparedStatement) describe(
+	descs *CommandDescriptionV2,
+	in codecs.Encoder,
+) {
+	s.card = descs.Card
+	s.in = in
+	s.outDesc = descs.Out
+	s.prepared = true
+}
+
+// codecs returns the codecs for running the statement as q.
+func (s *PreparedStatement) codecs(q *query) (*codecPair, error) {
+	if q.fmt == Null {
+		return &codecPair{in: s.in, out: codecs.NoOpDecoder}, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out, ok := s.decoders[q.outType]
+	if !ok {
+		var err error
+		path := codecs.Path(q.outType.String())
+		out, err = codecs.BuildDecoderV2(&s.outDesc, q.outType, path)
+		if err != nil {
+			return nil, &invalidArgumentError{msg: fmt.Sprintf(
+				"the \"out\" argument does not match query schema: %v", err)}
+		}
+
+		s.decoders[q.outType] = out
+	}
+
+	return &codecPair{in: s.in, out: out}, nil
+}
@@ -0,0 +1,68 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edgedb
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreparedStatementSkipsParse(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "SELECT {'a', 'b'}"
+	server.SetResult(cmd, mockStrResult("a", "b"))
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	stmt, err := client.Prepare(ctx, cmd, PrepareOptions{})
+	require.NoError(t, err)
+	assert.Len(t, server.Messages(Parse), 1)
+	assert.Empty(t, server.Commands())
+
+	var result []string
+	require.NoError(t, stmt.Query(ctx, &result))
+	assert.Equal(t, []string{"a", "b"}, result)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var out []string
+			assert.NoError(t, stmt.Query(ctx, &out))
+			assert.Equal(t, []string{"a", "b"}, out)
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, stmt.Execute(ctx))
+
+	assert.Len(t, server.Messages(Parse), 1, "the statement was parsed again")
+	assert.Len(t, server.Commands(), 6)
+
+	var single string
+	err = stmt.Query(ctx, &single)
+	assert.EqualError(t, err, "edgedb.InterfaceError: "+
+		"the \"out\" argument must be a pointer to a slice, got *string")
+}
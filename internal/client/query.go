@@ -41,11 +41,20 @@ type query struct {
 	cmd            string
 	fmt            Format
 	expCard        Cardinality
+	assertCard     Cardinality
 	args           []interface{}
 	capabilities   uint64
 	state          map[string]interface{}
+	annotations    header.Header1pX
 	parse          bool
 	warningHandler WarningHandler
+
+	// stmt is set when running a PreparedStatement.
+	stmt *PreparedStatement
+
+	// preprocessed is true if cmd must not be passed
+	// to the query preprocessor (again).
+	preprocessed bool
 }
 
 func (q *query) flat() bool {
@@ -73,6 +82,7 @@ func newQuery(
 	args []interface{},
 	capabilities uint64,
 	state map[string]interface{},
+	annotations header.Header1pX,
 	out interface{},
 	parse bool,
 	warningHandler WarningHandler,
@@ -98,6 +108,7 @@ func newQuery(
 			args:           args,
 			capabilities:   capabilities,
 			state:          state,
+			annotations:    annotations,
 			parse:          parse,
 			warningHandler: warningHandler,
 		}, nil
@@ -130,6 +141,7 @@ func newQuery(
 		args:           args,
 		capabilities:   capabilities,
 		state:          state,
+		annotations:    annotations,
 		parse:          parse,
 		warningHandler: warningHandler,
 	}
@@ -173,6 +185,7 @@ func runQuery(
 	out interface{},
 	args []interface{},
 	state map[string]interface{},
+	annotations map[string]string,
 	warningHandler WarningHandler,
 ) error {
 	if method == "QuerySingleJSON" {
@@ -191,6 +204,7 @@ func runQuery(
 		args,
 		c.capabilities1pX(),
 		state,
+		mergeAnnotations(ctx, annotations),
 		out,
 		true,
 		warningHandler,
@@ -199,6 +213,7 @@ func runQuery(
 		return err
 	}
 
+	q.assertCard = expectedCardinality(ctx)
 	err = c.granularFlow(ctx, q)
 
 	var edbErr Error
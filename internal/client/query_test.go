@@ -21,6 +21,7 @@ import (
 	"errors"
 	"math/big"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -131,7 +132,7 @@ func TestMissmatchedCardinality(t *testing.T) {
 
 func TestMissmatchedResultType(t *testing.T) {
 	type C struct { // nolint:unused
-		z int // nolint:structcheck
+		z int32 // nolint:structcheck
 	}
 
 	type B struct { // nolint:unused
@@ -149,7 +150,8 @@ func TestMissmatchedResultType(t *testing.T) {
 
 	expected := "edgedb.InvalidArgumentError: " +
 		"the \"out\" argument does not match query schema: " +
-		"expected edgedb.A.x.y.z to be int64 or edgedb.OptionalInt64 got int"
+		"expected edgedb.A.x.y.z to be int64, int or edgedb.OptionalInt64 " +
+		"got int32"
 	assert.EqualError(t, err, expected)
 }
 
@@ -1060,7 +1062,8 @@ func TestInvalidWithGlobals(t *testing.T) {
 	assert.EqualError(t, err, "edgedb.BinaryProtocolError: "+
 		"invalid connection state: "+
 		"expected state.globals.default::global_str to be "+
-		"string, edgedb.OptionalStr or StrMarshaler "+
+		"string, edgedb.OptionalStr, StrMarshaler, "+
+		"encoding.TextMarshaler, fmt.Stringer or a string kind "+
 		"got int")
 }
 
@@ -1179,3 +1182,211 @@ func TestWithWarningHandler(t *testing.T) {
 	require.NoError(t, err)
 	require.Greater(t, len(seen), 0)
 }
+
+func TestQueryArgumentMismatchIsNotSent(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "SELECT <str><int64>$0 ++ <str><int64>$1"
+	server.SetResult(cmd, mockInt64Args(mockStrResult("3"), 2))
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	var result string
+	err = client.QuerySingle(ctx, cmd, &result, int64(1))
+	var edbErr Error
+	require.True(t, errors.As(err, &edbErr), err)
+	assert.True(t, edbErr.Category(InvalidArgumentError))
+	assert.EqualError(t, err,
+		"edgedb.InvalidArgumentError: expected 2 arguments got 1")
+
+	err = client.QuerySingle(ctx, cmd, &result, "1")
+	assert.EqualError(t, err, "edgedb.InvalidArgumentError: "+
+		"expected 2 arguments got 1; expected args[0] to be int64, "+
+		"edgedb.OptionalInt64 or Int64Marshaler got string")
+
+	assert.Empty(t, server.Commands())
+
+	err = client.QuerySingle(ctx, cmd, &result, int64(1), int64(2))
+	require.NoError(t, err)
+	assert.Equal(t, "3", result)
+	assert.Equal(t, []string{cmd}, server.Commands())
+}
+
+func TestQueryPreprocessor(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "SELECT 'a'"
+	routed := "# route: replica\n" + cmd
+	server.SetResult(routed, mockStrResult("a"))
+
+	var calls []string
+	opts := server.Options()
+	opts.QueryPreprocessor = func(query string) string {
+		calls = append(calls, query)
+		return "# route: replica\n" + query
+	}
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, opts)
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	var result string
+	err = client.QuerySingle(ctx, cmd, &result)
+	require.NoError(t, err)
+	assert.Equal(t, "a", result)
+	assert.Equal(t, []string{cmd}, calls)
+
+	msgs := server.Messages(Parse)
+	require.Len(t, msgs, 1)
+	_, _, parsed := popQuery(msgs[0].Body)
+	assert.Equal(t, routed, parsed)
+	assert.Equal(t, []string{routed}, server.Commands())
+
+	calls = nil
+	err = client.Tx(ctx, func(ctx context.Context, tx *Tx) error {
+		return tx.QuerySingle(ctx, cmd, &result)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{cmd}, calls)
+
+	cmds := server.Commands()
+	require.Len(t, cmds, 4)
+	assert.True(t, strings.HasPrefix(cmds[1], "START TRANSACTION"), cmds[1])
+	assert.Equal(t, routed, cmds[2])
+	assert.Equal(t, "COMMIT;", cmds[3])
+}
+
+func TestWithoutStatementCache(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "SELECT 'a'"
+	server.SetResult(cmd, mockStrResult("a"))
+
+	ctx := context.Background()
+	query := func(opts Options) {
+		client, err := CreateClient(ctx, opts)
+		require.NoError(t, err)
+		defer client.Close() // nolint:errcheck
+
+		for i := 0; i < 2; i++ {
+			var result string
+			require.NoError(t, client.QuerySingle(ctx, cmd, &result))
+			assert.Equal(t, "a", result)
+		}
+	}
+
+	query(server.Options())
+	assert.Len(t, server.Messages(Parse), 1)
+
+	query(server.Options().WithoutStatementCache())
+	assert.Len(t, server.Messages(Parse), 3)
+	assert.Len(t, server.Commands(), 4)
+}
+
+func TestWithResultCache(t *testing.T) {
+	server := newMockServer(t)
+	read := "SELECT 'a'"
+	server.SetResult(read, mockStrResult("a"))
+	write := "SELECT (INSERT A).name"
+	result := mockStrResult("a")
+	result.Capabilities = capabilitiesModifications
+	server.SetResult(write, result)
+	startTx := NewTxOptions().WithIsolation(Serializable).startTxQuery()
+	server.SetResult(startTx, mockResult{Card: NoResult, TxState: 'T'})
+	inTx := "SELECT 'b'"
+	result = mockStrResult("b")
+	result.TxState = 'T'
+	server.SetResult(inTx, result)
+
+	ctx := context.Background()
+	opts := server.Options().WithResultCache(10, 100*time.Millisecond)
+	client, err := CreateClient(ctx, opts)
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	count := func(cmd string) int {
+		n := 0
+		for _, c := range server.Commands() {
+			if c == cmd {
+				n++
+			}
+		}
+		return n
+	}
+
+	for i := 0; i < 2; i++ {
+		var result string
+		require.NoError(t, client.QuerySingle(ctx, read, &result))
+		assert.Equal(t, "a", result)
+
+		require.NoError(t, client.QuerySingle(ctx, write, &result))
+		assert.Equal(t, "a", result)
+	}
+	assert.Equal(t, 1, count(read), "the second read must be cached")
+	assert.Equal(t, 2, count(write), "writes must not be cached")
+
+	time.Sleep(100 * time.Millisecond)
+	var str string
+	require.NoError(t, client.QuerySingle(ctx, read, &str))
+	assert.Equal(t, 2, count(read), "expired results must not be used")
+
+	err = client.Tx(ctx, func(ctx context.Context, tx *Tx) error {
+		for i := 0; i < 2; i++ {
+			if e := tx.QuerySingle(ctx, inTx, &str); e != nil {
+				return e
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count(inTx), "transactions must not be cached")
+}
+
+func TestWithExpectedCardinalityMatches(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "SELECT 'a'"
+	server.SetResult(cmd, mockStrResult("a"))
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	for _, card := range []Cardinality{One, AtMostOne, AtLeastOne, Many} {
+		var result []string
+		err = client.Query(WithExpectedCardinality(ctx, card), cmd, &result)
+		require.NoError(t, err, card)
+		assert.Equal(t, []string{"a"}, result)
+	}
+}
+
+func TestWithExpectedCardinalityMismatch(t *testing.T) {
+	server := newMockServer(t)
+	cmd := "SELECT {'a', 'b'}"
+	server.SetResult(cmd, mockStrResult("a", "b"))
+
+	ctx := context.Background()
+	client, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer client.Close() // nolint:errcheck
+
+	var result []string
+	err = client.Query(ctx, cmd, &result)
+	require.NoError(t, err)
+	assert.Equal(t, []string{cmd}, server.Commands())
+
+	// The second query uses the cached type ids.
+	for i := 0; i < 2; i++ {
+		err = client.Query(WithExpectedCardinality(ctx, One), cmd, &result)
+		var edbErr Error
+		require.True(t, errors.As(err, &edbErr), err)
+		assert.True(t, edbErr.Category(ResultCardinalityMismatchError))
+		assert.EqualError(t, err,
+			"edgedb.ResultCardinalityMismatchError: "+
+				"the query has cardinality Many "+
+				"which does not match the asserted cardinality One")
+	}
+
+	assert.Equal(t, []string{cmd}, server.Commands())
+}
@@ -123,7 +123,7 @@ func (c *protocolConnection) execScriptFlow(r *buff.Reader, q *query) error {
 		case CommandComplete:
 			decodeCommandCompleteMsg0pX(r)
 		case ReadyForCommand:
-			decodeReadyForCommandMsg(r)
+			c.decodeReadyForCommandMsg(r)
 			done.Signal()
 		case ErrorResponse:
 			err = wrapAll(err, decodeErrorResponseMsg(r, q.cmd))
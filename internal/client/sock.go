@@ -34,7 +34,13 @@ func connectAutoClosingSocket(
 		defer cancel()
 	}
 
-	conn, err := connectTLS(ctx, cfg)
+	var conn net.Conn
+	var err error
+	if cfg.plaintext {
+		conn, err = connectPlaintext(ctx, cfg)
+	} else {
+		conn, err = connectTLS(ctx, cfg)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +48,21 @@ func connectAutoClosingSocket(
 	return &autoClosingSocket{conn: conn}, nil
 }
 
+// connectPlaintext dials the server over raw TCP. There is no ALPN
+// negotiation without TLS, the edgedb-binary protocol is assumed.
+func connectPlaintext(
+	ctx context.Context,
+	cfg *connConfig,
+) (net.Conn, error) {
+	d := net.Dialer{}
+	conn, err := d.DialContext(ctx, cfg.addr.network, cfg.addr.address)
+	if err != nil {
+		return nil, wrapNetError(err)
+	}
+
+	return conn, nil
+}
+
 func connectTLS(
 	ctx context.Context,
 	cfg *connConfig,
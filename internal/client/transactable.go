@@ -19,12 +19,16 @@ package edgedb
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 )
 
+// txRollbackTimeout limits how long a best-effort rollback may take after the
+// context passed to Tx() is done.
+const txRollbackTimeout = 5 * time.Second
+
 type transactableConn struct {
 	*reconnectingConn
-	txOpts    TxOptions
 	retryOpts RetryOptions
 }
 
@@ -63,7 +67,9 @@ func (c *transactableConn) granularFlow(ctx context.Context, q *query) error {
 				return err
 			}
 
-			time.Sleep(rule.backoff(i))
+			if e := waitBackoff(ctx, rule.backoff(i)); e != nil {
+				return e
+			}
 			continue
 		}
 
@@ -76,7 +82,9 @@ func (c *transactableConn) granularFlow(ctx context.Context, q *query) error {
 func (c *transactableConn) tx(
 	ctx context.Context,
 	action TxBlock,
+	opts TxOptions,
 	state map[string]interface{},
+	annotations map[string]string,
 	warningHandler WarningHandler,
 ) (err error) {
 	conn, err := c.borrow("transaction")
@@ -100,8 +108,9 @@ func (c *transactableConn) tx(
 			tx := &Tx{
 				borrowableConn: borrowableConn{conn: conn},
 				txState:        &txState{},
-				options:        c.txOpts,
+				options:        opts,
 				state:          state,
+				annotations:    annotations,
 				warningHandler: warningHandler,
 			}
 			err = tx.start(ctx)
@@ -110,6 +119,25 @@ func (c *transactableConn) tx(
 			}
 
 			err = action(ctx, tx)
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				if errors.Is(err, ctxErr) {
+					err = nil
+				}
+
+				return wrapAll(
+					fmt.Errorf("edgedb: %w", ctxErr),
+					err,
+					rollbackDetached(tx, conn),
+				)
+			}
+
+			if err == nil &&
+				conn.transactionState == inFailedTransaction {
+				// action ignored the error of a failed query,
+				// the transaction can't be committed and is rolled back.
+				err = errInFailedTransaction
+			}
+
 			if err == nil {
 				err = tx.commit(ctx)
 				if errors.As(err, &edbErr) &&
@@ -138,7 +166,9 @@ func (c *transactableConn) tx(
 				return err
 			}
 
-			time.Sleep(rule.backoff(i))
+			if e := waitBackoff(ctx, rule.backoff(i)); e != nil {
+				return e
+			}
 			continue
 		}
 
@@ -147,3 +177,38 @@ func (c *transactableConn) tx(
 
 	return &clientError{msg: "unreachable"}
 }
+
+// rollbackDetached rolls back tx after the context passed to Tx() is done.
+// The context can not be used to roll back, a fresh one is used so the
+// transaction isn't left dangling. If the rollback fails the transaction
+// may still be open on the server so the connection is closed instead of
+// being reused.
+func rollbackDetached(tx *Tx, conn *protocolConnection) error {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		txRollbackTimeout,
+	)
+	defer cancel()
+
+	err := tx.rollback(ctx)
+	if err != nil && !conn.isClosed() {
+		_ = conn.soc.Close()
+	}
+
+	return err
+}
+
+// waitBackoff sleeps for d before the next retry attempt.
+// The wait is cut short if ctx is done so that the context deadline
+// bounds the total time spent retrying.
+func waitBackoff(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("edgedb: %w", ctx.Err())
+	}
+}
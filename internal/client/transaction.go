@@ -78,6 +78,7 @@ type Tx struct {
 	*txState
 	options        TxOptions
 	state          map[string]interface{}
+	annotations    map[string]string
 	warningHandler WarningHandler
 }
 
@@ -92,6 +93,7 @@ func (t *Tx) execute(
 		nil,
 		txCapabilities,
 		t.state,
+		mergeAnnotations(ctx, t.annotations),
 		nil,
 		false,
 		t.warningHandler,
@@ -100,6 +102,8 @@ func (t *Tx) execute(
 		return err
 	}
 
+	// transaction control statements are not user queries
+	q.preprocessed = true
 	err = t.borrowableConn.scriptFlow(ctx, q)
 
 	switch err {
@@ -159,7 +163,8 @@ func (t *Tx) granularFlow(ctx context.Context, q *query) error {
 	return t.borrowableConn.granularFlow(ctx, q)
 }
 
-// Execute an EdgeQL command (or commands).
+// Execute an EdgeQL command (or commands). Arguments are passed
+// positionally or as a single map of named arguments, like they are to Query.
 func (t *Tx) Execute(
 	ctx context.Context,
 	cmd string,
@@ -171,6 +176,7 @@ func (t *Tx) Execute(
 		args,
 		t.capabilities1pX(),
 		t.state,
+		mergeAnnotations(ctx, t.annotations),
 		nil,
 		true,
 		t.warningHandler,
@@ -197,6 +203,7 @@ func (t *Tx) Query(
 		out,
 		args,
 		t.state,
+		t.annotations,
 		t.warningHandler,
 	)
 }
@@ -219,6 +226,7 @@ func (t *Tx) QuerySingle(
 		out,
 		args,
 		t.state,
+		t.annotations,
 		t.warningHandler,
 	)
 }
@@ -238,6 +246,7 @@ func (t *Tx) QueryJSON(
 		out,
 		args,
 		t.state,
+		t.annotations,
 		t.warningHandler,
 	)
 }
@@ -259,6 +268,7 @@ func (t *Tx) QuerySingleJSON(
 		out,
 		args,
 		t.state,
+		t.annotations,
 		t.warningHandler,
 	)
 }
@@ -275,6 +285,7 @@ func (t *Tx) ExecuteSQL(
 		args,
 		t.capabilities1pX(),
 		t.state,
+		mergeAnnotations(ctx, t.annotations),
 		nil,
 		true,
 		t.warningHandler,
@@ -301,6 +312,7 @@ func (t *Tx) QuerySQL(
 		out,
 		args,
 		t.state,
+		t.annotations,
 		t.warningHandler,
 	)
 }
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
 	"github.com/stretchr/testify/assert"
@@ -91,6 +92,231 @@ func TestTxRollesBackOnUserError(t *testing.T) {
 	require.Equal(t, 0, len(testNames), "The transaction wasn't rolled back")
 }
 
+func TestTxRollesBackOnContextCancel(t *testing.T) {
+	server := newMockServer(t)
+	server.SetResult("SELECT 'hi';", mockStrResult("hi"))
+
+	p, err := CreateClient(context.Background(), server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = p.Tx(ctx, func(ctx context.Context, tx *Tx) error {
+		var result string
+		if e := tx.QuerySingle(ctx, "SELECT 'hi';", &result); e != nil {
+			return e
+		}
+
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	cmds := server.Commands()
+	require.NotEmpty(t, cmds)
+	assert.Equal(t, "ROLLBACK;", cmds[len(cmds)-1])
+	assert.NotContains(t, cmds, "COMMIT;")
+}
+
+func TestTxContextDoneWhileQueryIsBlocked(t *testing.T) {
+	server := newMockServer(t)
+	slow := mockStrResult("slow")
+	slow.Delay = time.Second
+	server.SetResult("SELECT 'slow';", slow)
+	server.SetResult("SELECT 'hi';", mockStrResult("hi"))
+
+	p, err := CreateClient(context.Background(), server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		100*time.Millisecond,
+	)
+	defer cancel()
+
+	var queryErr error
+	err = p.Tx(ctx, func(ctx context.Context, tx *Tx) error {
+		var result string
+		queryErr = tx.QuerySingle(ctx, "SELECT 'slow';", &result)
+		return queryErr
+	})
+	require.Error(t, queryErr)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), queryErr.Error())
+
+	// The deadline closed the connection so the rollback failed as well.
+	var closedErr *clientConnectionClosedError
+	assert.True(t, errors.As(err, &closedErr))
+	assert.NotContains(t, server.Commands(), "COMMIT;")
+
+	// The connection is not reused inside the transaction.
+	var result string
+	ctx = context.Background()
+	require.NoError(t, p.QuerySingle(ctx, "SELECT 'hi';", &result))
+	assert.Equal(t, "hi", result)
+}
+
+func TestTxContextErrorIsReportedOnce(t *testing.T) {
+	server := newMockServer(t)
+	server.SetResult("SELECT 'hi';", mockStrResult("hi"))
+
+	p, err := CreateClient(context.Background(), server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var queryErr error
+	err = p.Tx(ctx, func(ctx context.Context, tx *Tx) error {
+		cancel()
+		var result string
+		queryErr = tx.QuerySingle(ctx, "SELECT 'hi';", &result)
+		return queryErr
+	})
+	require.ErrorIs(t, queryErr, context.Canceled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, strings.Count(err.Error(), context.Canceled.Error()),
+		err.Error())
+}
+
+func TestTxFailedRollbackClosesConnection(t *testing.T) {
+	server := newMockServer(t)
+	server.SetResult("SELECT 'hi';", mockStrResult("hi"))
+	server.SetResult("ROLLBACK;", mockResult{
+		Card:        NoResult,
+		ExecErrCode: 0x07_00_00_00,
+		TxState:     'T',
+	})
+
+	p, err := CreateClient(context.Background(), server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = p.Tx(ctx, func(ctx context.Context, tx *Tx) error {
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "mock execute error")
+	require.Len(t, server.Messages(ClientHandshake), 1)
+
+	// The connection was left in a transaction so it must not be reused.
+	var result string
+	ctx = context.Background()
+	require.NoError(t, p.QuerySingle(ctx, "SELECT 'hi';", &result))
+	assert.Equal(t, "hi", result)
+	assert.Len(t, server.Messages(ClientHandshake), 2)
+}
+
+func TestTxInFailedState(t *testing.T) {
+	server := newMockServer(t)
+	server.SetResult("SELECT 1/0;", mockResult{
+		ErrMsg:  "division by zero",
+		TxState: inFailedTransaction,
+	})
+	server.SetResult("SELECT 'hi';", mockStrResult("hi"))
+
+	ctx := context.Background()
+	p, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	err = p.Tx(ctx, func(ctx context.Context, tx *Tx) error {
+		require.Error(t, tx.Execute(ctx, "SELECT 1/0;"))
+
+		var result string
+		return tx.QuerySingle(ctx, "SELECT 'hi';", &result)
+	})
+	assert.EqualError(t, err, "edgedb.InterfaceError: cannot run the query; "+
+		"the transaction is in an error state and must be rolled back")
+
+	cmds := server.Commands()
+	require.NotEmpty(t, cmds)
+	assert.NotContains(t, cmds, "SELECT 'hi';")
+	assert.Equal(t, "ROLLBACK;", cmds[len(cmds)-1])
+
+	// The connection is usable once the transaction is rolled back.
+	var result string
+	require.NoError(t, p.QuerySingle(ctx, "SELECT 'hi';", &result))
+	assert.Equal(t, "hi", result)
+}
+
+func TestTxInFailedStateWithSwallowedError(t *testing.T) {
+	server := newMockServer(t)
+	server.SetResult("SELECT 1/0;", mockResult{
+		ErrMsg:  "division by zero",
+		TxState: inFailedTransaction,
+	})
+	server.SetResult("SELECT 'hi';", mockStrResult("hi"))
+
+	ctx := context.Background()
+	p, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	err = p.Tx(ctx, func(ctx context.Context, tx *Tx) error {
+		_ = tx.Execute(ctx, "SELECT 1/0;")
+		return nil
+	})
+	assert.EqualError(t, err, "edgedb.InterfaceError: cannot run the query; "+
+		"the transaction is in an error state and must be rolled back")
+
+	cmds := server.Commands()
+	require.NotEmpty(t, cmds)
+	assert.NotContains(t, cmds, "COMMIT;")
+	assert.Equal(t, "ROLLBACK;", cmds[len(cmds)-1])
+
+	// The connection is reused once the transaction is rolled back.
+	for i := 0; i < 3; i++ {
+		var result string
+		require.NoError(t, p.QuerySingle(ctx, "SELECT 'hi';", &result))
+		assert.Equal(t, "hi", result)
+	}
+	assert.Len(t, server.Messages(ClientHandshake), 1)
+}
+
+func TestTxInFailedStateIsNotReleased(t *testing.T) {
+	server := newMockServer(t)
+	server.SetResult("SELECT 1/0;", mockResult{
+		ErrMsg:  "division by zero",
+		TxState: inFailedTransaction,
+	})
+	server.SetResult("ROLLBACK;", mockResult{
+		Card:        NoResult,
+		ExecErrCode: 0x07_00_00_00,
+		TxState:     inFailedTransaction,
+	})
+	server.SetResult("SELECT 'hi';", mockStrResult("hi"))
+
+	ctx := context.Background()
+	p, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	err = p.Tx(ctx, func(ctx context.Context, tx *Tx) error {
+		_ = tx.Execute(ctx, "SELECT 1/0;")
+		return nil
+	})
+	assert.EqualError(t, err, "edgedb.InterfaceError: cannot run the query; "+
+		"the transaction is in an error state and must be rolled back")
+	assert.Equal(t, "ROLLBACK;", server.Commands()[len(server.Commands())-1])
+	require.Len(t, server.Messages(ClientHandshake), 1)
+
+	// The rollback failed so the connection is terminated, not reused.
+	for i := 0; i < 3; i++ {
+		var result string
+		require.NoError(t, p.QuerySingle(ctx, "SELECT 'hi';", &result))
+		assert.Equal(t, "hi", result)
+	}
+	assert.Len(t, server.Messages(ClientHandshake), 2)
+}
+
 func TestTxCommits(t *testing.T) {
 	ctx := context.Background()
 	err := client.Tx(ctx, func(ctx context.Context, tx *Tx) error {
@@ -284,3 +510,49 @@ func TestSQLTx(t *testing.T) {
 		)
 	}
 }
+
+func TestClientTransactionIsolation(t *testing.T) {
+	server := newMockServer(t)
+
+	ctx := context.Background()
+	p, err := CreateClient(ctx, server.Options())
+	require.NoError(t, err)
+	defer p.Close() // nolint:errcheck
+
+	noOp := func(ctx context.Context, tx *Tx) error { return nil }
+	startTx := func() string {
+		cmds := server.Commands()
+		for i := len(cmds) - 1; i >= 0; i-- {
+			if strings.HasPrefix(cmds[i], "START TRANSACTION") {
+				return cmds[i]
+			}
+		}
+		return ""
+	}
+
+	require.NoError(t, p.Tx(ctx, noOp))
+	assert.Equal(t, "START TRANSACTION ISOLATION SERIALIZABLE, "+
+		"READ WRITE, NOT DEFERRABLE;", startTx())
+
+	repeatable := p.WithTransactionIsolation(RepeatableRead)
+	require.NoError(t, repeatable.Tx(ctx, noOp))
+	assert.Equal(t, "START TRANSACTION ISOLATION REPEATABLE READ, "+
+		"READ WRITE, NOT DEFERRABLE;", startTx())
+
+	// The client default applies to TxOptions without an isolation level.
+	readOnly := repeatable.WithTxOptions(NewTxOptions().WithReadOnly(true))
+	require.NoError(t, readOnly.Tx(ctx, noOp))
+	assert.Equal(t, "START TRANSACTION ISOLATION REPEATABLE READ, "+
+		"READ ONLY, NOT DEFERRABLE;", startTx())
+
+	// An explicit isolation level overrides the client default.
+	serializable := repeatable.WithTxOptions(
+		NewTxOptions().WithIsolation(Serializable))
+	require.NoError(t, serializable.Tx(ctx, noOp))
+	assert.Equal(t, "START TRANSACTION ISOLATION SERIALIZABLE, "+
+		"READ WRITE, NOT DEFERRABLE;", startTx())
+
+	assert.PanicsWithValue(t, `unknown isolation level: "snapshot"`, func() {
+		p.WithTransactionIsolation("snapshot")
+	})
+}
@@ -49,7 +49,7 @@ func TestMissmatchedUnmarshalerType(t *testing.T) {
 	assert.EqualError(t, err, "edgedb.InvalidArgumentError: "+
 		"the \"out\" argument does not match query schema: expected "+
 		"struct { Val edgedb.CustomInt32 \"edgedb:\\\"val\\\"\" }.val "+
-		"to be int64 or edgedb.OptionalInt64 got edgedb.CustomInt32")
+		"to be int64, int or edgedb.OptionalInt64 got edgedb.CustomInt32")
 	assert.Equal(t, []byte(nil), wrongType.Val.data)
 }
 
@@ -112,7 +112,7 @@ func printErrors(types []*errgen.Type) {
 func printCodeMap(types []*errgen.Type) {
 	fmt.Print(`
 
-func errorFromCode(code uint32, msg string) error {
+func knownErrorFromCode(code uint32, msg string) error {
 	switch code {`)
 
 	for _, typ := range types {
@@ -125,11 +125,7 @@ func errorFromCode(code uint32, msg string) error {
 	}
 	code := `
 	default:
-		return &unexpectedMessageError{
-			msg: fmt.Sprintf(
-				"invalid error code 0x%x with message %q", code, msg,
-			),
-		}
+		return nil
 	}
 }`
 	fmt.Print(code)
@@ -184,8 +180,6 @@ func main() {
 
 	fmt.Println()
 	fmt.Println("package edgedb")
-	fmt.Println()
-	fmt.Print(`import "fmt"`)
 	printTags(tags)
 	printCategories(types)
 	printErrors(types)
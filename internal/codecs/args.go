@@ -17,7 +17,9 @@
 package codecs
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/edgedb/edgedb-go/internal"
 	"github.com/edgedb/edgedb-go/internal/buff"
@@ -96,10 +98,11 @@ func (c *argsEncoder) Encode(
 		return fmt.Errorf("expected %v to be []interface{} got %T", path, val)
 	}
 
+	var errs []error
 	if len(in) != len(c.fields) {
-		return fmt.Errorf(
+		errs = append(errs, fmt.Errorf(
 			"expected %v arguments got %v", len(c.fields), len(in),
-		)
+		))
 	}
 
 	w.BeginBytes()
@@ -107,15 +110,22 @@ func (c *argsEncoder) Encode(
 	elmCount := len(c.fields)
 	w.PushUint32(uint32(elmCount))
 
-	var err error
 	for i, field := range c.fields {
+		if i == len(in) {
+			break
+		}
+
 		w.PushUint32(0) // reserved
-		err = field.encoder.Encode(w, in[i], path.AddIndex(i), field.required)
+		err := field.encoder.Encode(w, in[i], path.AddIndex(i), field.required)
 		if err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
 
+	if len(errs) > 0 {
+		return argumentErrors(errs)
+	}
+
 	w.EndBytes()
 	return nil
 }
@@ -155,10 +165,10 @@ func (c *kwargsEncoder) Encode(
 	w.BeginBytes()
 	w.PushUint32(uint32(elmCount))
 
-	var err error
+	var errs []error
 	for _, field := range c.fields {
 		w.PushUint32(0) // reserved
-		err = field.encoder.Encode(
+		err := field.encoder.Encode(
 			w,
 			in[field.name],
 			path.AddField(field.name),
@@ -166,10 +176,30 @@ func (c *kwargsEncoder) Encode(
 		)
 
 		if err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
 
+	if len(errs) > 0 {
+		return argumentErrors(errs)
+	}
+
 	w.EndBytes()
 	return nil
 }
+
+// argumentErrors combines the errors found while encoding arguments so that
+// every mismatched argument is reported at once. The written data is invalid
+// and must be discarded when an error is returned.
+func argumentErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+
+	return errors.New(strings.Join(msgs, "; "))
+}
@@ -0,0 +1,74 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"testing"
+
+	"github.com/edgedb/edgedb-go/internal"
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeArgs(t *testing.T, names []string, args ...interface{}) error {
+	desc := descriptor.V2{Type: descriptor.Object, ID: types.UUID{1}}
+	for _, name := range names {
+		desc.Fields = append(desc.Fields, &descriptor.FieldV2{
+			Name:     name,
+			Desc:     descriptor.V2{Type: descriptor.Scalar, ID: Int64ID},
+			Required: true,
+		})
+	}
+
+	version := internal.ProtocolVersion{Major: 2, Minor: 0}
+	encoder, err := BuildEncoderV2(&desc, version)
+	require.NoError(t, err)
+
+	w := buff.NewWriter(nil)
+	w.BeginMessage(0)
+	return encoder.Encode(w, args, Path("args"), true)
+}
+
+func TestEncodeArgsTooFew(t *testing.T) {
+	err := encodeArgs(t, []string{"0", "1"}, int64(1))
+	assert.EqualError(t, err, "expected 2 arguments got 1")
+}
+
+func TestEncodeArgsWrongType(t *testing.T) {
+	err := encodeArgs(t, []string{"0", "1"}, int64(1), "2")
+	assert.EqualError(t, err, "expected args[1] to be int64, "+
+		"edgedb.OptionalInt64 or Int64Marshaler got string")
+}
+
+func TestEncodeArgsListsAllMismatches(t *testing.T) {
+	err := encodeArgs(t, []string{"0", "1", "2"}, "1", int64(2))
+	assert.EqualError(t, err, "expected 3 arguments got 2; "+
+		"expected args[0] to be int64, "+
+		"edgedb.OptionalInt64 or Int64Marshaler got string")
+
+	err = encodeArgs(t, []string{"a", "b"}, map[string]interface{}{
+		"a": "1",
+		"b": 2.0,
+	})
+	assert.EqualError(t, err, "expected args.a to be int64, "+
+		"edgedb.OptionalInt64 or Int64Marshaler got string; "+
+		"expected args.b to be int64, "+
+		"edgedb.OptionalInt64 or Int64Marshaler got float64")
+}
@@ -119,12 +119,19 @@ func buildArrayDecoder(
 		)
 	}
 
-	child, err := BuildDecoder(desc.Fields[0].Desc, typ.Elem(), path)
+	elm := desc.Fields[0].Desc
+	child, err := BuildDecoder(elm, typ.Elem(), path)
 	if err != nil {
 		return nil, err
 	}
 
-	return &arrayDecoder{desc.ID, child, typ, calcStep(typ.Elem())}, nil
+	return &arrayDecoder{
+		id:      desc.ID,
+		child:   child,
+		typ:     typ,
+		step:    calcStep(typ.Elem()),
+		elmName: elm.ID.String(),
+	}, nil
 }
 
 func buildArrayDecoderV2(
@@ -138,12 +145,24 @@ func buildArrayDecoderV2(
 		)
 	}
 
-	child, err := BuildDecoderV2(&desc.Fields[0].Desc, typ.Elem(), path)
+	elm := &desc.Fields[0].Desc
+	child, err := BuildDecoderV2(elm, typ.Elem(), path)
 	if err != nil {
 		return nil, err
 	}
 
-	return &arrayDecoder{desc.ID, child, typ, calcStep(typ.Elem())}, nil
+	elmName := elm.Name
+	if elmName == "" {
+		elmName = elm.ID.String()
+	}
+
+	return &arrayDecoder{
+		id:      desc.ID,
+		child:   child,
+		typ:     typ,
+		step:    calcStep(typ.Elem()),
+		elmName: elmName,
+	}, nil
 }
 
 type arrayDecoder struct {
@@ -153,6 +172,9 @@ type arrayDecoder struct {
 
 	// step is the element width in bytes for a go array of type `Array.typ`.
 	step int
+
+	// elmName is the EdgeDB element type name used in error messages.
+	elmName string
 }
 
 func (c *arrayDecoder) DescriptorID() types.UUID { return c.id }
@@ -186,12 +208,18 @@ func (c *arrayDecoder) Decode(r *buff.Reader, out unsafe.Pointer) error {
 			pAdd(slice.Data, uintptr(i*c.step)),
 		)
 		if err != nil {
-			return err
+			return c.elementError(i, err)
 		}
 	}
 	return nil
 }
 
+func (c *arrayDecoder) elementError(i int, err error) error {
+	return fmt.Errorf(
+		"cannot decode element %v of array<%v> into %v: %w",
+		i, c.elmName, c.typ.Elem(), err)
+}
+
 func (c *arrayDecoder) DecodeMissing(out unsafe.Pointer) {
 	slice := (*sliceHeader)(out)
 	slice.Data = nilPointer
@@ -0,0 +1,200 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/edgedb/edgedb-go/internal"
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var strArrayDescriptor = descriptor.V2{
+	Type: descriptor.Array,
+	ID:   types.UUID{1},
+	Fields: []*descriptor.FieldV2{{
+		Desc: descriptor.V2{Type: descriptor.Scalar, ID: StrID},
+	}},
+}
+
+func TestDecodeStrArrayEmbeddedNull(t *testing.T) {
+	data := []byte{
+		0, 0, 0, 1, // dimension count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 3, // upper bound
+		0, 0, 0, 1, // lower bound
+		0, 0, 0, 3, 'a', 0, 'b',
+		0, 0, 0, 2, 0, 0,
+		0, 0, 0, 2, 'c', 0,
+	}
+
+	codec, err := BuildDecoderV2(
+		&strArrayDescriptor,
+		reflect.TypeOf([]string{}),
+		Path("x"),
+	)
+	require.NoError(t, err)
+
+	var result []string
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a\x00b", "\x00\x00", "c\x00"}, result)
+}
+
+func TestEncodeStrArrayEmbeddedNull(t *testing.T) {
+	codec, err := BuildEncoderV2(
+		&strArrayDescriptor,
+		internal.ProtocolVersion{Major: 2, Minor: 0},
+	)
+	require.NoError(t, err)
+
+	w := buff.NewWriter(nil)
+	w.BeginMessage(0)
+	err = codec.Encode(w, []string{"a\x00b", "\x00"}, Path("x"), true)
+	require.NoError(t, err)
+	w.EndMessage()
+
+	// message type, message length and data length
+	data := w.Unwrap()[9:]
+	assert.Equal(t, []byte{
+		0, 0, 0, 1, // dimension count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 2, // upper bound
+		0, 0, 0, 1, // lower bound
+		0, 0, 0, 3, 'a', 0, 'b',
+		0, 0, 0, 1, 0,
+	}, data)
+}
+
+func TestDecodeArrayElementTypeMismatch(t *testing.T) {
+	desc := descriptor.V2{
+		Type: descriptor.Array,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.FieldV2{{
+			Desc: descriptor.V2{Type: descriptor.Scalar, ID: Int64ID},
+		}},
+	}
+
+	_, err := BuildDecoderV2(&desc, reflect.TypeOf([]int16{}), Path("x"))
+	assert.EqualError(t, err, "expected x to be int64, int or "+
+		"edgedb.OptionalInt64 got int16")
+}
+
+func TestDecodeArrayElementError(t *testing.T) {
+	desc := descriptor.V2{
+		Type: descriptor.Array,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.FieldV2{{
+			Desc: descriptor.V2{
+				Type: descriptor.Scalar,
+				ID:   JSONID,
+				Name: "std::json",
+			},
+		}},
+	}
+
+	codec, err := BuildDecoderV2(&desc, reflect.TypeOf([]int{}), Path("x"))
+	require.NoError(t, err)
+
+	data := []byte{
+		0, 0, 0, 1, // dimension count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 2, // upper bound
+		0, 0, 0, 1, // lower bound
+		0, 0, 0, 2, 1, '1',
+		0, 0, 0, 4, 1, '"', 'x', '"',
+	}
+
+	var result []int
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(),
+		"cannot decode element 1 of array<std::json> into int: ")
+}
+
+func TestDecodeArrayOfNamedTuples(t *testing.T) {
+	desc := descriptor.V2{
+		Type: descriptor.Array,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.FieldV2{{
+			Desc: descriptor.V2{
+				Type: descriptor.NamedTuple,
+				ID:   types.UUID{2},
+				Fields: []*descriptor.FieldV2{
+					{
+						Name: "a",
+						Desc: descriptor.V2{
+							Type: descriptor.Scalar,
+							ID:   Int64ID,
+						},
+					},
+					{
+						Name: "b",
+						Desc: descriptor.V2{
+							Type: descriptor.Scalar,
+							ID:   StrID,
+						},
+					},
+				},
+			},
+		}},
+	}
+
+	data := []byte{
+		0, 0, 0, 1, // dimension count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 2, // upper bound
+		0, 0, 0, 1, // lower bound
+		0, 0, 0, 29, // element length
+		0, 0, 0, 2, // tuple element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 1, 'x',
+		0, 0, 0, 30, // element length
+		0, 0, 0, 2, // tuple element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 2,
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 2, 'y', 'z',
+	}
+
+	// The struct fields are not in tuple order,
+	// they are matched to the tuple elements by name.
+	type pair struct {
+		B string `edgedb:"b"`
+		A int64  `edgedb:"a"`
+	}
+
+	codec, err := BuildDecoderV2(&desc, reflect.TypeOf([]pair{}), Path("x"))
+	require.NoError(t, err)
+
+	var result []pair
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, []pair{{"x", 1}, {"yz", 2}}, result)
+}
@@ -17,13 +17,89 @@
 package codecs
 
 import (
+	"reflect"
 	"testing"
 	"unsafe"
 
 	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
 	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func TestDecodeSequence(t *testing.T) {
+	sequence := descriptor.V2{
+		Type: descriptor.Scalar,
+		ID:   SequenceID,
+		Name: "std::sequence",
+	}
+	ticket := descriptor.V2{
+		Type:      descriptor.Scalar,
+		ID:        types.UUID{1},
+		Name:      "default::ticket_no",
+		Ancestors: []*descriptor.FieldV2{{Desc: sequence}},
+	}
+	data := []byte{0, 0, 0, 0, 0, 0, 0x30, 0x39}
+
+	for _, desc := range []*descriptor.V2{&sequence, &ticket} {
+		codec, err := BuildDecoderV2(desc, reflect.TypeOf(int64(0)), Path("x"))
+		require.NoError(t, err, desc.Name)
+
+		var result int64
+		err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+		require.NoError(t, err, desc.Name)
+		assert.Equal(t, int64(12345), result, desc.Name)
+
+		encoder, err := BuildScalarEncoderV2(desc)
+		require.NoError(t, err, desc.Name)
+		assert.Equal(t, Int64ID, encoder.DescriptorID(), desc.Name)
+	}
+
+	codec, err := BuildDecoder(
+		descriptor.Descriptor{Type: descriptor.BaseScalar, ID: SequenceID},
+		reflect.TypeOf(int64(0)),
+		Path("x"),
+	)
+	require.NoError(t, err)
+
+	var result int64
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, int64(12345), result)
+}
+
+func TestDecodeInt64IntoInt(t *testing.T) {
+	// SELECT count(Foo) returns std::int64.
+	desc := descriptor.V2{
+		Type: descriptor.Scalar,
+		ID:   Int64ID,
+		Name: "std::int64",
+	}
+	data := []byte{0, 0, 0, 0, 0, 0, 0x30, 0x39}
+
+	codec, err := BuildDecoderV2(&desc, reflect.TypeOf(0), Path("count"))
+	require.NoError(t, err)
+
+	var result int
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, 12345, result)
+
+	codec, err = BuildDecoderV2(
+		&desc,
+		reflect.TypeOf((*int)(nil)),
+		Path("count"),
+	)
+	require.NoError(t, err)
+
+	var ptr *int
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&ptr))
+	require.NoError(t, err)
+	require.NotNil(t, ptr)
+	assert.Equal(t, 12345, *ptr)
+}
+
 func BenchmarkDecodeUUID(b *testing.B) {
 	data := []byte{
 		0, 1, 2, 3, 3, 2, 1, 0, 8, 7, 6, 5, 5, 6, 7, 8,
@@ -28,6 +28,10 @@ import (
 	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
 )
 
+// ErrNumericOutOfRange is wrapped by errors returned when a decoded value
+// does not fit into the destination type.
+var ErrNumericOutOfRange = errors.New("numeric value out of range")
+
 // Encoder can encode objects into the data wire format.
 type Encoder interface {
 	DescriptorID() types.UUID
@@ -164,14 +168,41 @@ func GetScalarDescriptorV2(
 	return desc
 }
 
+// equivalentScalarIDs maps scalars that share the wire format of a std scalar
+// to that scalar. This includes std::sequence, which extends int64, and the
+// std::pg scalars exposed by the SQL compatibility layer.
+var equivalentScalarIDs = map[string]types.UUID{
+	"std::sequence":        Int64ID,
+	"std::pg::json":        JSONID,
+	"std::pg::timestamptz": DateTimeID,
+	"std::pg::timestamp":   LocalDTID,
+	"std::pg::date":        LocalDateID,
+	"std::pg::interval":    RelativeDurationID,
+}
+
+// getEquivalentScalarDescriptor replaces a scalar descriptor with a descriptor
+// for the equivalent std scalar so that the existing codecs can be used.
+func getEquivalentScalarDescriptor(desc *descriptor.V2) *descriptor.V2 {
+	id, ok := equivalentScalarIDs[desc.Name]
+	if !ok {
+		return desc
+	}
+
+	return &descriptor.V2{Type: descriptor.Scalar, ID: id, Name: desc.Name}
+}
+
 // BuildScalarEncoder builds a scalar encoder.
 func BuildScalarEncoder(desc descriptor.Descriptor) (Encoder, error) {
 	if desc.Type == descriptor.Scalar {
 		desc = GetScalarDescriptor(desc)
 	}
 
+	if desc.ID == SequenceID {
+		desc.ID = Int64ID
+	}
+
 	if desc.ID == DecimalID {
-		return &decimalEncoder{}, nil
+		return &DecimalCodec{}, nil
 	}
 
 	if desc.Type == descriptor.Enum {
@@ -196,9 +227,7 @@ func BuildScalarEncoder(desc descriptor.Descriptor) (Encoder, error) {
 	case Float64ID:
 		return &Float64Codec{}, nil
 	case DecimalID:
-		return nil, errors.New("decimal codec not implemented. " +
-			"Consider implementing your own edgedb.DecimalMarshaler " +
-			"and edgedb.DecimalUnmarshaler.")
+		return &DecimalCodec{}, nil
 	case BoolID:
 		return &BoolCodec{}, nil
 	case DateTimeID:
@@ -230,11 +259,11 @@ func BuildScalarEncoder(desc descriptor.Descriptor) (Encoder, error) {
 // BuildScalarEncoderV2 builds a scalar encoder.
 func BuildScalarEncoderV2(desc *descriptor.V2) (Encoder, error) {
 	if desc.Type == descriptor.Scalar {
-		desc = GetScalarDescriptorV2(desc)
+		desc = getEquivalentScalarDescriptor(GetScalarDescriptorV2(desc))
 	}
 
 	if desc.ID == DecimalID {
-		return &decimalEncoder{}, nil
+		return &DecimalCodec{}, nil
 	}
 
 	if desc.Type == descriptor.Enum {
@@ -259,9 +288,7 @@ func BuildScalarEncoderV2(desc *descriptor.V2) (Encoder, error) {
 	case Float64ID:
 		return &Float64Codec{}, nil
 	case DecimalID:
-		return nil, errors.New("decimal codec not implemented. " +
-			"Consider implementing your own edgedb.DecimalMarshaler " +
-			"and edgedb.DecimalUnmarshaler.")
+		return &DecimalCodec{}, nil
 	case BoolID:
 		return &BoolCodec{}, nil
 	case DateTimeID:
@@ -300,6 +327,10 @@ func BuildDecoder(
 		return noOpDecoder{}, nil
 	}
 
+	if decodesIntoPointer(typ) {
+		return buildPointerDecoder(desc, typ, path)
+	}
+
 	switch desc.Type {
 	case descriptor.Set:
 		return buildSetDecoder(desc, typ, path)
@@ -332,6 +363,10 @@ func BuildDecoderV2(
 		return noOpDecoder{}, nil
 	}
 
+	if decodesIntoPointer(typ) {
+		return buildPointerDecoderV2(desc, typ, path)
+	}
+
 	switch desc.Type {
 	case descriptor.Set:
 		return buildSetDecoderV2(desc, typ, path)
@@ -365,6 +400,10 @@ func buildScalarDecoder(
 		desc = GetScalarDescriptor(desc)
 	}
 
+	if desc.ID == SequenceID {
+		desc.ID = Int64ID
+	}
+
 	decoder, ok, err := buildUnmarshaler(desc, typ)
 	if err != nil {
 		return decoder, err
@@ -439,8 +478,10 @@ func buildScalarDecoder(
 			return &Int64Codec{}, nil
 		case optionalInt64Type:
 			return &optionalInt64Decoder{}, nil
+		case intType:
+			return &intDecoder{path}, nil
 		default:
-			expectedType = "int64 or edgedb.OptionalInt64"
+			expectedType = "int64, int or edgedb.OptionalInt64"
 		}
 	case Float32ID:
 		switch typ {
@@ -461,9 +502,14 @@ func buildScalarDecoder(
 			expectedType = "float64 or edgedb.OptionalFloat64"
 		}
 	case DecimalID:
-		return nil, errors.New("decimal codec not implemented. " +
-			"Consider implementing your own edgedb.DecimalMarshaler " +
-			"and edgedb.DecimalUnmarshaler.")
+		switch typ {
+		case strType:
+			return &DecimalCodec{}, nil
+		case optionalStrType:
+			return &optionalDecimalDecoder{}, nil
+		default:
+			expectedType = "string or edgedb.OptionalStr"
+		}
 	case BoolID:
 		switch typ {
 		case boolType:
@@ -488,6 +534,8 @@ func buildScalarDecoder(
 			return &LocalDateTimeCodec{}, nil
 		case optionalLocalDateTimeType:
 			return &optionalLocalDateTimeDecoder{}, nil
+		case dateTimeType:
+			return &localDateTimeInLocationDecoder{path: path}, nil
 		default:
 			expectedType = "edgedb.LocalDateTime or " +
 				"edgedb.OptionalLocalDateTime"
@@ -516,8 +564,11 @@ func buildScalarDecoder(
 			return &DurationCodec{}, nil
 		case optionalDurationType:
 			return &optionalDurationDecoder{}, nil
+		case timeDurationType:
+			return &timeDurationDecoder{path}, nil
 		default:
-			expectedType = "edgedb.Duration or edgedb.OptionalDuration"
+			expectedType = "edgedb.Duration, edgedb.OptionalDuration " +
+				"or time.Duration"
 		}
 	case JSONID:
 		ptr := reflect.PointerTo(typ)
@@ -531,9 +582,9 @@ func buildScalarDecoder(
 			return &optionalUnmarshalerJSONDecoder{typ: typ}, nil
 		case ptr.Implements(optionalScalarUnmarshalerType):
 			return &optionalScalarUnmarshalerJSONDecoder{typ: typ}, nil
-		case typ.Kind() == reflect.Slice:
-			fallthrough
-		case typ.Kind() == reflect.Interface:
+		case typ.Kind() == reflect.Slice,
+			typ.Kind() == reflect.Map,
+			typ.Kind() == reflect.Interface:
 			return &optionalNilableJSONDecoder{typ: typ}, nil
 		default:
 			return &JSONCodec{typ: typ}, nil
@@ -582,6 +633,12 @@ func buildScalarDecoder(
 	}
 
 TypeMissmatch:
+	if encoder, e := BuildScalarEncoder(desc); e == nil {
+		if decoder, ok := buildTextUnmarshaler(encoder, typ); ok {
+			return decoder, nil
+		}
+	}
+
 	return nil, fmt.Errorf(
 		"expected %v to be %v got %v", path, expectedType, typ,
 	)
@@ -593,7 +650,7 @@ func buildScalarDecoderV2(
 	path Path,
 ) (Decoder, error) {
 	if desc.Type == descriptor.Scalar {
-		desc = GetScalarDescriptorV2(desc)
+		desc = getEquivalentScalarDescriptor(GetScalarDescriptorV2(desc))
 	}
 
 	decoder, ok, err := buildUnmarshalerV2(desc, typ)
@@ -670,8 +727,10 @@ func buildScalarDecoderV2(
 			return &Int64Codec{}, nil
 		case optionalInt64Type:
 			return &optionalInt64Decoder{}, nil
+		case intType:
+			return &intDecoder{path}, nil
 		default:
-			expectedType = "int64 or edgedb.OptionalInt64"
+			expectedType = "int64, int or edgedb.OptionalInt64"
 		}
 	case Float32ID:
 		switch typ {
@@ -692,9 +751,14 @@ func buildScalarDecoderV2(
 			expectedType = "float64 or edgedb.OptionalFloat64"
 		}
 	case DecimalID:
-		return nil, errors.New("decimal codec not implemented. " +
-			"Consider implementing your own edgedb.DecimalMarshaler " +
-			"and edgedb.DecimalUnmarshaler.")
+		switch typ {
+		case strType:
+			return &DecimalCodec{}, nil
+		case optionalStrType:
+			return &optionalDecimalDecoder{}, nil
+		default:
+			expectedType = "string or edgedb.OptionalStr"
+		}
 	case BoolID:
 		switch typ {
 		case boolType:
@@ -719,6 +783,8 @@ func buildScalarDecoderV2(
 			return &LocalDateTimeCodec{}, nil
 		case optionalLocalDateTimeType:
 			return &optionalLocalDateTimeDecoder{}, nil
+		case dateTimeType:
+			return &localDateTimeInLocationDecoder{path: path}, nil
 		default:
 			expectedType = "edgedb.LocalDateTime or " +
 				"edgedb.OptionalLocalDateTime"
@@ -747,8 +813,11 @@ func buildScalarDecoderV2(
 			return &DurationCodec{}, nil
 		case optionalDurationType:
 			return &optionalDurationDecoder{}, nil
+		case timeDurationType:
+			return &timeDurationDecoder{path}, nil
 		default:
-			expectedType = "edgedb.Duration or edgedb.OptionalDuration"
+			expectedType = "edgedb.Duration, edgedb.OptionalDuration " +
+				"or time.Duration"
 		}
 	case JSONID:
 		ptr := reflect.PointerTo(typ)
@@ -762,9 +831,9 @@ func buildScalarDecoderV2(
 			return &optionalUnmarshalerJSONDecoder{typ: typ}, nil
 		case ptr.Implements(optionalScalarUnmarshalerType):
 			return &optionalScalarUnmarshalerJSONDecoder{typ: typ}, nil
-		case typ.Kind() == reflect.Slice:
-			fallthrough
-		case typ.Kind() == reflect.Interface:
+		case typ.Kind() == reflect.Slice,
+			typ.Kind() == reflect.Map,
+			typ.Kind() == reflect.Interface:
 			return &optionalNilableJSONDecoder{typ: typ}, nil
 		default:
 			return &JSONCodec{typ: typ}, nil
@@ -813,6 +882,12 @@ func buildScalarDecoderV2(
 	}
 
 TypeMissmatch:
+	if encoder, e := BuildScalarEncoderV2(desc); e == nil {
+		if decoder, ok := buildTextUnmarshaler(encoder, typ); ok {
+			return decoder, nil
+		}
+	}
+
 	return nil, fmt.Errorf(
 		"expected %v to be %v got %v", path, expectedType, typ,
 	)
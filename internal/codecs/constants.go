@@ -66,12 +66,15 @@ var (
 	JSONID = types.UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0x0f}
 	// BigIntID is the bigint type descriptor ID
 	BigIntID = types.UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0x10}
+	// SequenceID is the std::sequence type descriptor ID
+	SequenceID = types.UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0xff}
 	// MemoryID is the cfg::memory type descriptor ID
 	MemoryID = types.UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0x30}
 
 	int16Type                 = reflect.TypeOf(int16(0))
 	int32Type                 = reflect.TypeOf(int32(0))
 	int64Type                 = reflect.TypeOf(int64(0))
+	intType                   = reflect.TypeOf(int(0))
 	float32Type               = reflect.TypeOf(float32(0))
 	float64Type               = reflect.TypeOf(float64(0))
 	optionalInt16Type         = reflect.TypeOf(types.OptionalInt16{})
@@ -92,6 +95,7 @@ var (
 	localDateType             = reflect.TypeOf(types.LocalDate{})
 	localTimeType             = reflect.TypeOf(types.LocalTime{})
 	durationType              = reflect.TypeOf(types.Duration(0))
+	timeDurationType          = reflect.TypeOf(time.Duration(0))
 	relativeDurationType      = reflect.TypeOf(types.RelativeDuration{})
 	dateDurationType          = reflect.TypeOf(types.DateDuration{})
 	bigIntType                = reflect.TypeOf(&big.Int{})
@@ -18,7 +18,9 @@ package codecs
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"sync/atomic"
 	"time"
 	"unsafe"
 
@@ -240,6 +242,58 @@ func (c *optionalLocalDateTimeDecoder) DecodeMissing(out unsafe.Pointer) {
 
 func (c *optionalLocalDateTimeDecoder) DecodePresent(_ unsafe.Pointer) {}
 
+// localDateTimeLocation holds the *time.Location set by
+// SetLocalDateTimeLocation. It is read when values are decoded so that
+// cached decoders use the current location.
+var localDateTimeLocation atomic.Value
+
+// SetLocalDateTimeLocation sets the location that cal::local_datetime values
+// are attached to when they are decoded into time.Time. Decoding into
+// time.Time fails while the location is nil, which is the default.
+func SetLocalDateTimeLocation(loc *time.Location) {
+	localDateTimeLocation.Store(loc)
+}
+
+// localDateTimeInLocationDecoder decodes cal::local_datetime values into
+// time.Time values with the same wall clock reading in the location set by
+// SetLocalDateTimeLocation.
+type localDateTimeInLocationDecoder struct {
+	path Path
+}
+
+func (c *localDateTimeInLocationDecoder) DescriptorID() types.UUID {
+	return LocalDTID
+}
+
+func (c *localDateTimeInLocationDecoder) Decode(
+	r *buff.Reader,
+	out unsafe.Pointer,
+) error {
+	val := int64(r.PopUint64())
+	loc, _ := localDateTimeLocation.Load().(*time.Location)
+	if loc == nil {
+		return fmt.Errorf("expected %v to be edgedb.LocalDateTime or "+
+			"edgedb.OptionalLocalDateTime got time.Time, "+
+			"decoding into time.Time requires a local datetime location",
+			c.path)
+	}
+
+	seconds := val / 1_000_000
+	microseconds := val % 1_000_000
+	wall := time.Unix(946_684_800+seconds, 1_000*microseconds).UTC()
+	*(*time.Time)(out) = time.Date(
+		wall.Year(),
+		wall.Month(),
+		wall.Day(),
+		wall.Hour(),
+		wall.Minute(),
+		wall.Second(),
+		wall.Nanosecond(),
+		loc,
+	)
+	return nil
+}
+
 // LocalDateCodec encodes/decodes LocalDate values.
 type LocalDateCodec struct{}
 
@@ -530,6 +584,33 @@ func (c *optionalDurationDecoder) DecodeMissing(out unsafe.Pointer) {
 	(*types.OptionalDuration)(out).Unset()
 }
 
+// timeDurationDecoder decodes std::duration into time.Duration.
+// std::duration is stored in microseconds, values that can not be represented
+// in int64 nanoseconds are an error.
+type timeDurationDecoder struct {
+	path Path
+}
+
+func (c *timeDurationDecoder) DescriptorID() types.UUID { return DurationID }
+
+func (c *timeDurationDecoder) Decode(
+	r *buff.Reader,
+	out unsafe.Pointer,
+) error {
+	microseconds := int64(r.PopUint64())
+	r.Discard(8) // reserved
+
+	if microseconds > math.MaxInt64/1_000 ||
+		microseconds < math.MinInt64/1_000 {
+		return fmt.Errorf(
+			"%w: %v microseconds does not fit in %v (time.Duration)",
+			ErrNumericOutOfRange, microseconds, c.path)
+	}
+
+	*(*time.Duration)(out) = time.Duration(microseconds * 1_000)
+	return nil
+}
+
 // RelativeDurationCodec encodes/decodes RelativeDuration values.
 type RelativeDurationCodec struct{}
 
@@ -18,10 +18,16 @@ package codecs
 
 import (
 	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
 	"testing"
 	"time"
+	"unsafe"
 
 	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -185,3 +191,141 @@ func TestRoundingGoTime(t *testing.T) {
 		})
 	}
 }
+
+func decodeTimeDuration(t *testing.T, microseconds int64) (
+	time.Duration, error,
+) {
+	desc := descriptor.Descriptor{Type: descriptor.BaseScalar, ID: DurationID}
+	codec, err := BuildDecoder(
+		desc,
+		reflect.TypeOf(time.Duration(0)),
+		Path("duration"),
+	)
+	require.NoError(t, err)
+
+	data := make([]byte, 16)
+	binary.BigEndian.PutUint64(data, uint64(microseconds))
+
+	var result time.Duration
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	return result, err
+}
+
+func TestDecodeTimeDuration(t *testing.T) {
+	result, err := decodeTimeDuration(t, 3_000_001)
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second+time.Microsecond, result)
+
+	result, err = decodeTimeDuration(t, -1)
+	require.NoError(t, err)
+	assert.Equal(t, -time.Microsecond, result)
+
+	result, err = decodeTimeDuration(t, math.MaxInt64/1_000)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(math.MaxInt64/1_000*1_000), result)
+}
+
+func TestDecodeTimeDurationOverflow(t *testing.T) {
+	for _, microseconds := range []int64{
+		math.MaxInt64/1_000 + 1,
+		math.MinInt64/1_000 - 1,
+		math.MaxInt64,
+	} {
+		_, err := decodeTimeDuration(t, microseconds)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrNumericOutOfRange), err)
+	}
+}
+
+func TestDecodeSQLRecordPgTimestamp(t *testing.T) {
+	desc := descriptor.V2{
+		Type: descriptor.SQLRecord,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.FieldV2{
+			{
+				Name: "created",
+				Desc: descriptor.V2{
+					Type: descriptor.Scalar,
+					ID:   types.UUID{2},
+					Name: "std::pg::timestamptz",
+				},
+				Required: true,
+			},
+			{
+				Name: "day",
+				Desc: descriptor.V2{
+					Type: descriptor.Scalar,
+					ID:   types.UUID{3},
+					Name: "std::pg::date",
+				},
+				Required: true,
+			},
+		},
+	}
+
+	type record struct {
+		Created time.Time       `edgedb:"created"`
+		Day     types.LocalDate `edgedb:"day"`
+	}
+
+	codec, err := BuildDecoderV2(&desc, reflect.TypeOf(record{}), Path("x"))
+	require.NoError(t, err)
+
+	data := []byte{
+		0, 0, 0, 2, // element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 4, 0, 0, 0, 1,
+	}
+
+	var result record
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+
+	created := time.Date(2000, 1, 1, 0, 0, 0, 1_000, time.UTC)
+	assert.True(t, created.Equal(result.Created), result.Created)
+	assert.Equal(t, types.NewLocalDate(2000, 1, 2), result.Day)
+}
+
+func TestDecodeLocalDateTimeInLocation(t *testing.T) {
+	desc := descriptor.V2{Type: descriptor.Scalar, ID: LocalDTID}
+	typ := reflect.TypeOf(time.Time{})
+
+	// decoders are cached so the location is read when decoding.
+	codec, err := BuildDecoderV2(&desc, typ, Path("x"))
+	require.NoError(t, err)
+
+	// 2000-01-02T03:04:05.000006
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(97_445_000_006))
+
+	var result time.Time
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	assert.EqualError(t, err, "expected x to be edgedb.LocalDateTime or "+
+		"edgedb.OptionalLocalDateTime got time.Time, "+
+		"decoding into time.Time requires a local datetime location")
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	SetLocalDateTimeLocation(loc)
+	defer SetLocalDateTimeLocation(nil)
+
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2000, 1, 2, 3, 4, 5, 6_000, loc), result)
+	assert.Equal(t, loc, result.Location())
+
+	// 1999-12-31T23:59:59.5
+	binary.BigEndian.PutUint64(data, uint64(0xffff_ffff_fff8_5ee0))
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(1999, 12, 31, 23, 59, 59, 500_000_000, loc),
+		result)
+
+	other := time.FixedZone("UTC-5", -5*60*60)
+	SetLocalDateTimeLocation(other)
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(1999, 12, 31, 23, 59, 59, 500_000_000, other),
+		result)
+}
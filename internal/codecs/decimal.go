@@ -0,0 +1,301 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+	"github.com/edgedb/edgedb-go/internal/marshal"
+)
+
+const (
+	decimalPositive uint16 = 0x0000
+	decimalNegative uint16 = 0x4000
+
+	// decimalMaxDScale is the largest display scale
+	// the decimal wire format can represent.
+	decimalMaxDScale = 0x3fff
+)
+
+// DecimalCodec encodes/decodes decimals as strings. The string
+// representation is exact, it has as many fractional digits as the
+// decimal's display scale and keeps the sign of negative zero.
+type DecimalCodec struct{}
+
+// Type returns the type the codec encodes/decodes
+func (c *DecimalCodec) Type() reflect.Type { return strType }
+
+// DescriptorID returns the codecs descriptor id.
+func (c *DecimalCodec) DescriptorID() types.UUID { return DecimalID }
+
+// Decode decodes a decimal into a string.
+func (c *DecimalCodec) Decode(r *buff.Reader, out unsafe.Pointer) error {
+	str, err := decodeDecimal(r)
+	if err != nil {
+		return err
+	}
+
+	*(*string)(out) = str
+	return nil
+}
+
+func decodeDecimal(r *buff.Reader) (string, error) {
+	n := int(r.PopUint16())
+	weight := int(int16(r.PopUint16()))
+	sign := r.PopUint16()
+	dscale := int(r.PopUint16())
+
+	digits := make([]uint16, n)
+	for i := range digits {
+		digits[i] = r.PopUint16()
+		if digits[i] > 9999 {
+			return "", fmt.Errorf("invalid decimal digit group %v", digits[i])
+		}
+	}
+
+	digit := func(i int) uint16 {
+		if i < 0 || i >= n {
+			return 0
+		}
+		return digits[i]
+	}
+
+	var buf []byte
+	switch sign {
+	case decimalPositive:
+	case decimalNegative:
+		buf = append(buf, '-')
+	default:
+		return "", fmt.Errorf("unexpected decimal sign 0x%x", sign)
+	}
+
+	// Leading zero groups are skipped so that a non-normalized value
+	// does not gain spurious leading zeros.
+	leading := true
+	for i := 0; i <= weight; i++ {
+		d := digit(i)
+		switch {
+		case leading && d == 0:
+		case leading:
+			buf = strconv.AppendUint(buf, uint64(d), 10)
+			leading = false
+		default:
+			buf = appendDigitGroup(buf, d)
+		}
+	}
+
+	if leading {
+		buf = append(buf, '0')
+	}
+
+	// Every digit group is written out so that digits beyond the display
+	// scale can be checked before the string is truncated to the scale.
+	point := len(buf)
+	buf = append(buf, '.')
+	for i := weight + 1; i < n || len(buf)-point-1 < dscale; i++ {
+		buf = appendDigitGroup(buf, digit(i))
+	}
+
+	end := point + 1 + dscale
+	if strings.Trim(string(buf[end:]), "0") != "" {
+		return "", fmt.Errorf(
+			"decimal has more fractional digits than its scale %v", dscale)
+	}
+
+	if dscale == 0 {
+		end = point
+	}
+
+	return string(buf[:end]), nil
+}
+
+func appendDigitGroup(buf []byte, d uint16) []byte {
+	return append(buf,
+		byte('0'+d/1000),
+		byte('0'+d/100%10),
+		byte('0'+d/10%10),
+		byte('0'+d%10),
+	)
+}
+
+type optionalDecimalMarshaler interface {
+	marshal.DecimalMarshaler
+	marshal.OptionalMarshaler
+}
+
+// Encode encodes a decimal.
+func (c *DecimalCodec) Encode(
+	w *buff.Writer,
+	val interface{},
+	path Path,
+	required bool,
+) error {
+	switch in := val.(type) {
+	case string:
+		return c.encodeData(w, in, path)
+	case types.OptionalStr:
+		str, ok := in.Get()
+		return encodeOptional(w, !ok, required,
+			func() error { return c.encodeData(w, str, path) },
+			func() error {
+				return missingValueError("edgedb.OptionalStr", path)
+			})
+	case optionalDecimalMarshaler:
+		return encodeOptional(w, in.Missing(), required,
+			func() error { return c.encodeMarshaler(w, in, path) },
+			func() error { return missingValueError(in, path) })
+	case marshal.DecimalMarshaler:
+		return c.encodeMarshaler(w, in, path)
+	default:
+		return fmt.Errorf("expected %v to be string, edgedb.OptionalStr "+
+			"or DecimalMarshaler got %T", path, val)
+	}
+}
+
+func (c *DecimalCodec) encodeData(
+	w *buff.Writer,
+	val string,
+	path Path,
+) error {
+	str := val
+	sign := decimalPositive
+	switch {
+	case strings.HasPrefix(str, "-"):
+		sign = decimalNegative
+		str = str[1:]
+	case strings.HasPrefix(str, "+"):
+		str = str[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(str, ".")
+	if integer == "" && fraction == "" ||
+		!isDecimalDigits(integer) ||
+		!isDecimalDigits(fraction) {
+		return fmt.Errorf("expected %v to be a decimal got %q", path, val)
+	}
+
+	if len(fraction) > decimalMaxDScale {
+		return fmt.Errorf(
+			"decimal at %v has more than %v fractional digits",
+			path, decimalMaxDScale)
+	}
+
+	integer = strings.TrimLeft(integer, "0")
+	integer = strings.Repeat("0", (4-len(integer)%4)%4) + integer
+	padded := fraction + strings.Repeat("0", (4-len(fraction)%4)%4)
+
+	digits := make([]uint16, 0, (len(integer)+len(padded))/4)
+	for _, part := range []string{integer, padded} {
+		for i := 0; i < len(part); i += 4 {
+			d, _ := strconv.ParseUint(part[i:i+4], 10, 16)
+			digits = append(digits, uint16(d))
+		}
+	}
+
+	weight := len(integer)/4 - 1
+	for len(digits) > 0 && digits[0] == 0 {
+		digits = digits[1:]
+		weight--
+	}
+
+	for len(digits) > 0 && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+
+	if len(digits) == 0 {
+		weight = 0
+	}
+
+	if weight > 0x7fff || weight < -0x8000 || len(digits) > 0xffff {
+		return fmt.Errorf("decimal at %v is out of range", path)
+	}
+
+	w.BeginBytes()
+	w.PushUint16(uint16(len(digits)))
+	w.PushUint16(uint16(int16(weight)))
+	w.PushUint16(sign)
+	w.PushUint16(uint16(len(fraction)))
+	for _, d := range digits {
+		w.PushUint16(d)
+	}
+	w.EndBytes()
+	return nil
+}
+
+func isDecimalDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *DecimalCodec) encodeMarshaler(
+	w *buff.Writer,
+	val marshal.DecimalMarshaler,
+	path Path,
+) error {
+	data, err := val.MarshalEdgeDBDecimal()
+	if err != nil {
+		return err
+	}
+	if len(data) < 8 {
+		return wrongNumberOfBytesError(val, path, "at least 8", len(data))
+	}
+	w.BeginBytes()
+	w.PushBytes(data)
+	w.EndBytes()
+	return nil
+}
+
+type optionalDecimal struct {
+	val string
+	set bool
+}
+
+type optionalDecimalDecoder struct{}
+
+func (c *optionalDecimalDecoder) DescriptorID() types.UUID { return DecimalID }
+
+func (c *optionalDecimalDecoder) Decode(
+	r *buff.Reader,
+	out unsafe.Pointer,
+) error {
+	str, err := decodeDecimal(r)
+	if err != nil {
+		return err
+	}
+
+	opdec := (*optionalDecimal)(out)
+	opdec.val = str
+	opdec.set = true
+	return nil
+}
+
+func (c *optionalDecimalDecoder) DecodeMissing(out unsafe.Pointer) {
+	(*types.OptionalStr)(out).Unset()
+}
+
+func (c *optionalDecimalDecoder) DecodePresent(_ unsafe.Pointer) {}
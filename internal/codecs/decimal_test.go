@@ -0,0 +1,133 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"fmt"
+	"testing"
+	"unsafe"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decimalData(weight int16, sign, dscale uint16, digits ...uint16) []byte {
+	w := buff.NewWriter(nil)
+	w.PushUint16(uint16(len(digits)))
+	w.PushUint16(uint16(weight))
+	w.PushUint16(sign)
+	w.PushUint16(dscale)
+	for _, d := range digits {
+		w.PushUint16(d)
+	}
+	return w.Unwrap()
+}
+
+func decodeDecimalStr(t *testing.T, data []byte) (string, error) {
+	t.Helper()
+
+	var result string
+	codec := &DecimalCodec{}
+	err := codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	return result, err
+}
+
+func encodeDecimalStr(t *testing.T, val string) []byte {
+	t.Helper()
+
+	w := buff.NewWriter(nil)
+	w.BeginMessage(0)
+	codec := &DecimalCodec{}
+	require.NoError(t, codec.Encode(w, val, Path("x"), true))
+	w.EndMessage()
+
+	// message type, message length and data length
+	return w.Unwrap()[9:]
+}
+
+func TestDecimalCodec(t *testing.T) {
+	cases := []struct {
+		str  string
+		data []byte
+	}{
+		{"0", decimalData(0, decimalPositive, 0)},
+		{"-0", decimalData(0, decimalNegative, 0)},
+		{"-0.00", decimalData(0, decimalNegative, 2)},
+		{"1", decimalData(0, decimalPositive, 0, 1)},
+		{"-12.5", decimalData(0, decimalNegative, 1, 12, 5000)},
+		{"10000", decimalData(1, decimalPositive, 0, 1)},
+		{"100000002", decimalData(2, decimalPositive, 0, 1, 0, 2)},
+		{"10002.0003", decimalData(1, decimalPositive, 4, 1, 2, 3)},
+		{"0.0001", decimalData(-1, decimalPositive, 4, 1)},
+		{"0.00000001", decimalData(-2, decimalPositive, 8, 1)},
+		{"0.100", decimalData(-1, decimalPositive, 3, 1000)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.str, func(t *testing.T) {
+			result, err := decodeDecimalStr(t, c.data)
+			require.NoError(t, err)
+			assert.Equal(t, c.str, result)
+			assert.Equal(t, c.data, encodeDecimalStr(t, c.str))
+		})
+	}
+}
+
+func TestDecodeDecimalNotNormalized(t *testing.T) {
+	// leading and trailing zero groups
+	data := decimalData(2, decimalPositive, 4, 0, 12, 34, 0)
+	result, err := decodeDecimalStr(t, data)
+	require.NoError(t, err)
+	assert.Equal(t, "120034.0000", result)
+}
+
+func TestDecimalMaxDScale(t *testing.T) {
+	data := decimalData(-1, decimalPositive, decimalMaxDScale, 1)
+	result, err := decodeDecimalStr(t, data)
+	require.NoError(t, err)
+	require.Len(t, result, 2+decimalMaxDScale)
+	assert.Equal(t, "0.0001", result[:6])
+	assert.Equal(t, fmt.Sprintf("%0*d", decimalMaxDScale-4, 0), result[6:])
+	assert.Equal(t, data, encodeDecimalStr(t, result))
+
+	w := buff.NewWriter(nil)
+	w.BeginMessage(0)
+	err = (&DecimalCodec{}).Encode(w, result+"0", Path("x"), true)
+	assert.EqualError(t, err,
+		"decimal at x has more than 16383 fractional digits")
+}
+
+func TestDecodeDecimalDigitsBeyondDScale(t *testing.T) {
+	_, err := decodeDecimalStr(t, decimalData(-1, decimalPositive, 2, 1234))
+	assert.EqualError(t, err,
+		"decimal has more fractional digits than its scale 2")
+
+	_, err = decodeDecimalStr(t, decimalData(0, decimalPositive, 0, 1, 5))
+	assert.EqualError(t, err,
+		"decimal has more fractional digits than its scale 0")
+}
+
+func TestEncodeDecimalInvalid(t *testing.T) {
+	for _, val := range []string{"", "-", ".", "1e3", "1.2.3", "--1", "NaN"} {
+		w := buff.NewWriter(nil)
+		w.BeginMessage(0)
+		err := (&DecimalCodec{}).Encode(w, val, Path("x"), true)
+		assert.EqualError(t, err,
+			fmt.Sprintf("expected x to be a decimal got %q", val))
+	}
+}
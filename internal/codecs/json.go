@@ -17,9 +17,14 @@
 package codecs
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 	"unsafe"
 
 	"github.com/edgedb/edgedb-go/internal/buff"
@@ -27,6 +32,247 @@ import (
 	"github.com/edgedb/edgedb-go/internal/marshal"
 )
 
+var (
+	jsonUseNumber = false
+	jsonNull      = []byte("null")
+)
+
+// SetJSONUseNumber sets whether numbers in json values decoded into
+// interface{} are represented as json.Number instead of float64.
+// The default is float64.
+func SetJSONUseNumber(useNumber bool) {
+	jsonUseNumber = useNumber
+}
+
+// isNumberType returns true if typ is an integer, a float or *big.Int.
+func isNumberType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return typ == bigIntType
+	}
+}
+
+// unquoteJSONNumber returns the number in data if data is a json string
+// holding a number. Large integers such as bigint values are encoded
+// as json strings to preserve their precision.
+func unquoteJSONNumber(data []byte) []byte {
+	if len(data) == 0 || data[0] != '"' {
+		return data
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return data
+	}
+
+	var n json.Number
+	if err := json.Unmarshal([]byte(s), &n); err != nil {
+		return data
+	}
+
+	return []byte(s)
+}
+
+func unmarshalJSON(data []byte, v interface{}) error {
+	if isNumberType(reflect.TypeOf(v).Elem()) {
+		data = unquoteJSONNumber(data)
+	}
+
+	err := decodeJSONData(data, v)
+	return jsonFieldError(data, reflect.TypeOf(v), err)
+}
+
+func decodeJSONData(data []byte, v interface{}) error {
+	if !jsonUseNumber {
+		return json.Unmarshal(data, v)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	if dec.More() {
+		return errors.New(
+			"invalid json: unexpected data after top-level value",
+		)
+	}
+
+	return nil
+}
+
+// malformedValueError is implemented by errors that keep the value
+// that could not be unmarshaled, e.g. a malformed uuid.
+type malformedValueError interface {
+	error
+	MalformedValue() string
+}
+
+// jsonFieldError adds the json field holding the malformed value to err.
+func jsonFieldError(data []byte, typ reflect.Type, err error) error {
+	var malformed malformedValueError
+	if !errors.As(err, &malformed) {
+		return err
+	}
+
+	val := malformed.MalformedValue()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	field, ok := malformedJSONField(dec, typ, "")
+	if !ok || field == "" {
+		return err
+	}
+
+	return fmt.Errorf(
+		"invalid value %q for json field %v: %w", val, field, err,
+	)
+}
+
+// malformedJSONField reads the next json value from dec while following
+// the type it is decoded into. It returns the path of the first string that
+// typ can not unmarshal from text, e.g. owner.id or items[1], in the same
+// order as encoding/json decodes them. path is the path of the value.
+func malformedJSONField(
+	dec *json.Decoder,
+	typ reflect.Type,
+	path string,
+) (string, bool) {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return "", false
+	}
+
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '[' {
+			return malformedJSONElement(dec, typ, path)
+		}
+
+		if tok == '{' {
+			return malformedJSONMember(dec, typ, path)
+		}
+	case string:
+		if typ == nil ||
+			!reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+			return "", false
+		}
+
+		v := reflect.New(typ).Interface().(encoding.TextUnmarshaler)
+		if v.UnmarshalText([]byte(tok)) != nil {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// malformedJSONElement is malformedJSONField for the elements of an array.
+func malformedJSONElement(
+	dec *json.Decoder,
+	typ reflect.Type,
+	path string,
+) (string, bool) {
+	var elem reflect.Type
+	if typ != nil && (typ.Kind() == reflect.Slice ||
+		typ.Kind() == reflect.Array) {
+		elem = typ.Elem()
+	}
+
+	for i := 0; dec.More(); i++ {
+		p := path + "[" + strconv.Itoa(i) + "]"
+		if field, ok := malformedJSONField(dec, elem, p); ok {
+			return field, true
+		}
+	}
+
+	_, _ = dec.Token() // ]
+	return "", false
+}
+
+// malformedJSONMember is malformedJSONField for the members of an object.
+func malformedJSONMember(
+	dec *json.Decoder,
+	typ reflect.Type,
+	path string,
+) (string, bool) {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", false
+		}
+
+		key := tok.(string)
+		p := key
+		if path != "" {
+			p = path + "." + key
+		}
+
+		member := jsonMemberType(typ, key)
+		if field, ok := malformedJSONField(dec, member, p); ok {
+			return field, true
+		}
+	}
+
+	_, _ = dec.Token() // }
+	return "", false
+}
+
+// jsonMemberType returns the type that the object member key is decoded
+// into when the object is decoded into typ, or nil if it is unknown.
+func jsonMemberType(typ reflect.Type, key string) reflect.Type {
+	if typ == nil {
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Map:
+		return typ.Elem()
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	var folded reflect.Type
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			if t := jsonMemberType(field.Type, key); t != nil {
+				return t
+			}
+			continue
+		}
+
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if name == key {
+			return field.Type
+		}
+
+		if folded == nil && strings.EqualFold(name, key) {
+			folded = field.Type
+		}
+	}
+
+	return folded
+}
+
 // JSONCodec encodes/decodes json.
 type JSONCodec struct {
 	baseJSONDecoder
@@ -43,8 +289,13 @@ func (c *JSONCodec) Decode(r *buff.Reader, out unsafe.Pointer) error {
 	}
 
 	if c.typ != bytesType {
-		ptr := reflect.NewAt(c.typ, out).Interface()
-		return json.Unmarshal(r.Buf, ptr)
+		ptr := reflect.NewAt(c.typ, out)
+		if bytes.Equal(bytes.TrimSpace(r.Buf), jsonNull) {
+			// json.Unmarshal ignores null for non-nilable types,
+			// the previous value must not be kept.
+			ptr.Elem().Set(reflect.Zero(c.typ))
+		}
+		return unmarshalJSON(r.Buf, ptr.Interface())
 	}
 
 	n := len(r.Buf)
@@ -148,7 +399,7 @@ func (c *optionalNilableJSONDecoder) Decode(
 	}
 
 	ptr := reflect.NewAt(c.typ, out).Interface()
-	return json.Unmarshal(r.Buf, ptr)
+	return unmarshalJSON(r.Buf, ptr)
 }
 
 func (c *optionalNilableJSONDecoder) DecodeMissing(out unsafe.Pointer) {
@@ -173,7 +424,7 @@ func (c *optionalUnmarshalerJSONDecoder) Decode(
 
 	ptr := reflect.NewAt(c.typ, out).Interface()
 	ptr.(marshal.OptionalUnmarshaler).SetMissing(false)
-	return json.Unmarshal(r.Buf, ptr)
+	return unmarshalJSON(r.Buf, ptr)
 }
 
 func (c *optionalUnmarshalerJSONDecoder) DecodeMissing(out unsafe.Pointer) {
@@ -195,7 +446,7 @@ func (c *optionalScalarUnmarshalerJSONDecoder) Decode(
 	}
 
 	ptr := reflect.NewAt(c.typ, out).Interface()
-	return json.Unmarshal(r.Buf, ptr)
+	return unmarshalJSON(r.Buf, ptr)
 }
 
 func (c *optionalScalarUnmarshalerJSONDecoder) DecodeMissing(
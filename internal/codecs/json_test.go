@@ -0,0 +1,289 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeJSON(t *testing.T, data string, out interface{}) {
+	desc := descriptor.V2{Type: descriptor.Scalar, ID: JSONID}
+	val := reflect.ValueOf(out).Elem()
+	codec, err := BuildDecoderV2(&desc, val.Type(), Path("json"))
+	require.NoError(t, err)
+
+	buf := append([]byte{1}, data...) // json format
+	err = codec.Decode(
+		buff.SimpleReader(buf),
+		unsafe.Pointer(val.UnsafeAddr()),
+	)
+	require.NoError(t, err)
+}
+
+func TestDecodeJSONTopLevelScalars(t *testing.T) {
+	var i int64
+	decodeJSON(t, `42`, &i)
+	assert.Equal(t, int64(42), i)
+
+	var f float64
+	decodeJSON(t, `-1.5e3`, &f)
+	assert.Equal(t, -1500.0, f)
+
+	var s string
+	decodeJSON(t, `"hello"`, &s)
+	assert.Equal(t, "hello", s)
+
+	var b bool
+	decodeJSON(t, `true`, &b)
+	assert.True(t, b)
+
+	var a []int64
+	decodeJSON(t, `[1, 2, 3]`, &a)
+	assert.Equal(t, []int64{1, 2, 3}, a)
+
+	var m map[string]int64
+	decodeJSON(t, `{"a": 1}`, &m)
+	assert.Equal(t, map[string]int64{"a": 1}, m)
+
+	var raw []byte
+	decodeJSON(t, `42`, &raw)
+	assert.Equal(t, []byte(`42`), raw)
+}
+
+func TestDecodeJSONTopLevelNull(t *testing.T) {
+	var x interface{} = "previous"
+	decodeJSON(t, `null`, &x)
+	assert.Nil(t, x)
+
+	a := []int64{1}
+	decodeJSON(t, `null`, &a)
+	assert.Nil(t, a)
+
+	m := map[string]int64{"a": 1}
+	decodeJSON(t, `null`, &m)
+	assert.Nil(t, m)
+
+	type someStruct struct {
+		A int64 `json:"a"`
+	}
+
+	p := &someStruct{A: 1}
+	decodeJSON(t, `null`, &p)
+	assert.Nil(t, p)
+
+	decodeJSON(t, `{"a": 2}`, &p)
+	assert.Equal(t, &someStruct{A: 2}, p)
+
+	s := someStruct{A: 1}
+	decodeJSON(t, `null`, &s)
+	assert.Equal(t, someStruct{}, s)
+
+	i := int64(1)
+	decodeJSON(t, `null`, &i)
+	assert.Equal(t, int64(0), i)
+
+	var raw *[]byte
+	decodeJSON(t, `null`, &raw)
+	assert.Equal(t, []byte(`null`), *raw)
+}
+
+func TestDecodeJSONTopLevelAny(t *testing.T) {
+	samples := []struct {
+		data     string
+		expected interface{}
+	}{
+		{`42`, 42.0},
+		{`"str"`, "str"},
+		{`false`, false},
+		{`[1, "a"]`, []interface{}{1.0, "a"}},
+		{`{"a": null}`, map[string]interface{}{"a": nil}},
+	}
+
+	for _, sample := range samples {
+		t.Run(sample.data, func(t *testing.T) {
+			var x interface{}
+			decodeJSON(t, sample.data, &x)
+			assert.Equal(t, sample.expected, x)
+		})
+	}
+}
+
+func TestDecodeJSONUseNumber(t *testing.T) {
+	data := `{"id": 9007199254740993, "ratio": 0.5}`
+
+	var x interface{}
+	decodeJSON(t, data, &x)
+	assert.Equal(t, map[string]interface{}{
+		"id":    float64(9007199254740992),
+		"ratio": 0.5,
+	}, x)
+
+	SetJSONUseNumber(true)
+	defer SetJSONUseNumber(false)
+
+	decodeJSON(t, data, &x)
+	assert.Equal(t, map[string]interface{}{
+		"id":    json.Number("9007199254740993"),
+		"ratio": json.Number("0.5"),
+	}, x)
+
+	id, err := x.(map[string]interface{})["id"].(json.Number).Int64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), id)
+}
+
+func TestDecodeJSONStringNumber(t *testing.T) {
+	var b *big.Int
+	decodeJSON(t, `"123456789012345678901234567890"`, &b)
+	expected, ok := new(big.Int).SetString(
+		"123456789012345678901234567890", 10)
+	require.True(t, ok)
+	assert.Equal(t, expected, b)
+
+	var i int64
+	decodeJSON(t, `"-9223372036854775808"`, &i)
+	assert.Equal(t, int64(math.MinInt64), i)
+
+	decodeJSON(t, `42`, &b)
+	assert.Equal(t, big.NewInt(42), b)
+
+	// strings that are not numbers are still rejected
+	desc := descriptor.V2{Type: descriptor.Scalar, ID: JSONID}
+	codec, err := BuildDecoderV2(&desc, reflect.TypeOf(i), Path("json"))
+	require.NoError(t, err)
+	data := append([]byte{1}, `"abc"`...)
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&i))
+	assert.EqualError(t, err,
+		"json: cannot unmarshal string into Go value of type int64")
+
+	var s string
+	decodeJSON(t, `"123"`, &s)
+	assert.Equal(t, "123", s)
+}
+
+func TestDecodeJSONUUID(t *testing.T) {
+	type Owner struct {
+		ID   types.CompactUUID `json:"id"`
+		Name string            `json:"name"`
+	}
+
+	type Pet struct {
+		ID    types.UUID `json:"id"`
+		Owner Owner      `json:"owner"`
+		Tags  []string   `json:"tags"`
+	}
+
+	id := types.UUID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	data := `{
+		"id": "00010203-0405-0607-0809-0a0b0c0d0e0f",
+		"owner": {"id": "00010203-0405-0607-0809-0a0b0c0d0e0f", "name": "x"},
+		"tags": ["00010203-0405-0607-0809-0a0b0c0d0e0f"]
+	}`
+
+	var pet Pet
+	decodeJSON(t, data, &pet)
+	assert.Equal(t, Pet{
+		ID:    id,
+		Owner: Owner{ID: types.CompactUUID(id), Name: "x"},
+		Tags:  []string{"00010203-0405-0607-0809-0a0b0c0d0e0f"},
+	}, pet)
+	assert.Equal(t, "000102030405060708090a0b0c0d0e0f", pet.Owner.ID.String())
+
+	desc := descriptor.V2{Type: descriptor.Scalar, ID: JSONID}
+	codec, err := BuildDecoderV2(&desc, reflect.TypeOf(pet), Path("json"))
+	require.NoError(t, err)
+	buf := append([]byte{1}, `{"tags": [], "owner": {"id": "zz"}}`...)
+	err = codec.Decode(buff.SimpleReader(buf), unsafe.Pointer(&pet))
+	assert.EqualError(t, err, `invalid value "zz" for json field owner.id: `+
+		"malformed edgedb.UUID")
+}
+
+func TestDecodeJSONUUIDFieldWithRepeatedValue(t *testing.T) {
+	type Owner struct {
+		ID types.UUID `json:"id"`
+	}
+
+	type Pet struct {
+		Name  string   `json:"name"`
+		Tags  []string `json:"tags"`
+		Owner Owner    `json:"owner"`
+	}
+
+	desc := descriptor.V2{Type: descriptor.Scalar, ID: JSONID}
+	codec, err := BuildDecoderV2(&desc, reflect.TypeOf(Pet{}), Path("json"))
+	require.NoError(t, err)
+
+	// "zz" is also the value of name and of a tag,
+	// only owner.id can not hold it.
+	data := `{"name": "zz", "tags": ["zz"], "owner": {"id": "zz"}}`
+	buf := append([]byte{1}, data...)
+	var pet Pet
+	err = codec.Decode(buff.SimpleReader(buf), unsafe.Pointer(&pet))
+	assert.EqualError(t, err, `invalid value "zz" for json field owner.id: `+
+		"malformed edgedb.UUID")
+}
+
+func TestMalformedJSONField(t *testing.T) {
+	type Embedded struct {
+		Ref types.UUID
+	}
+
+	type Item struct {
+		Embedded
+		ID   types.UUID            `json:"id"`
+		Name string                `json:"name"`
+		Refs map[string]types.UUID `json:"refs"`
+	}
+
+	samples := []struct {
+		data     string
+		typ      reflect.Type
+		expected string
+	}{
+		{`"x"`, reflect.TypeOf(types.UUID{}), ""},
+		{`"x"`, reflect.TypeOf(""), ""},
+		{`{"name": "x", "id": "x"}`, reflect.TypeOf(Item{}), "id"},
+		{`{"ID": "x"}`, reflect.TypeOf(&Item{}), "ID"},
+		{`{"ref": "x"}`, reflect.TypeOf(Item{}), "ref"},
+		{`{"refs": {"a": "x"}}`, reflect.TypeOf(Item{}), "refs.a"},
+		{
+			`[{"name": "x"}, {"id": "x"}]`,
+			reflect.TypeOf([]Item{}),
+			"[1].id",
+		},
+		{`{"name": "x", "other": "x"}`, reflect.TypeOf(Item{}), ""},
+		{`{"id": "x"}`, reflect.TypeOf(map[string]string{}), ""},
+	}
+
+	for _, s := range samples {
+		dec := json.NewDecoder(strings.NewReader(s.data))
+		field, _ := malformedJSONField(dec, s.typ, "")
+		assert.Equal(t, s.expected, field, s.data)
+	}
+}
@@ -218,6 +218,11 @@ func (c *namedTupleDecoder) Decode(r *buff.Reader, out unsafe.Pointer) error {
 
 		elmLen := r.PopUint32()
 		if elmLen == 0xffffffff {
+			// element length -1 means the element is null. Optional and
+			// pointer fields are marked missing, others are left unchanged.
+			if decoder, ok := field.decoder.(OptionalDecoder); ok {
+				decoder.DecodeMissing(pAdd(out, field.offset))
+			}
 			continue
 		}
 
@@ -273,6 +273,26 @@ func (c *optionalInt64Decoder) DecodeMissing(out unsafe.Pointer) {
 
 func (c *optionalInt64Decoder) DecodePresent(_ unsafe.Pointer) {}
 
+// intDecoder decodes std::int64 into int.
+// On platforms where int is 32 bits values that do not fit are an error.
+type intDecoder struct {
+	path Path
+}
+
+func (c *intDecoder) DescriptorID() types.UUID { return Int64ID }
+
+func (c *intDecoder) Decode(r *buff.Reader, out unsafe.Pointer) error {
+	val := int64(r.PopUint64())
+	if val > math.MaxInt || val < math.MinInt {
+		return fmt.Errorf(
+			"%w: %v does not fit in %v (int)",
+			ErrNumericOutOfRange, val, c.path)
+	}
+
+	*(*int)(out) = int(val)
+	return nil
+}
+
 // Float32Codec encodes/decodes float32.
 type Float32Codec struct{}
 
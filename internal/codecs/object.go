@@ -63,13 +63,18 @@ func buildObjectDecoder(
 
 	fields := make([]*DecoderField, len(desc.Fields))
 
+	names := make([]string, len(desc.Fields))
 	for i, field := range desc.Fields {
-		sf, ok := introspect.StructField(typ, field.Name)
-		if !ok {
-			return nil, fmt.Errorf(
-				"expected %v to have a field named %q", path, field.Name,
-			)
-		}
+		names[i] = field.Name
+	}
+
+	structFields, err := objectStructFields(typ, names, path)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, field := range desc.Fields {
+		sf := structFields[i]
 
 		child, err := BuildDecoder(
 			field.Desc,
@@ -121,13 +126,18 @@ func buildObjectDecoderV2(
 
 	fields := make([]*DecoderField, len(desc.Fields))
 
+	names := make([]string, len(desc.Fields))
 	for i, field := range desc.Fields {
-		sf, ok := introspect.StructField(typ, field.Name)
-		if !ok {
-			return nil, fmt.Errorf(
-				"expected %v to have a field named %q", path, field.Name,
-			)
-		}
+		names[i] = field.Name
+	}
+
+	structFields, err := objectStructFields(typ, names, path)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, field := range desc.Fields {
+		sf := structFields[i]
 
 		child, err := BuildDecoderV2(
 			&field.Desc,
@@ -166,6 +176,70 @@ func buildObjectDecoderV2(
 	return &decoder, nil
 }
 
+// FieldInfo describes the struct field that a shape field is decoded into.
+type FieldInfo struct {
+	// Name is the name of the go struct field.
+	Name string
+}
+
+// FieldResolver returns the struct fields of typ keyed by shape field name.
+// It returns nil for types that it does not know about.
+type FieldResolver func(typ reflect.Type) map[string]FieldInfo
+
+var fieldResolver FieldResolver
+
+// SetFieldResolver sets the resolver that is consulted before edgedb tags
+// and field names when decoding objects into structs. Shape fields missing
+// from the resolver's mapping are matched by tag and name as usual.
+func SetFieldResolver(resolver FieldResolver) {
+	fieldResolver = resolver
+}
+
+// objectStructFields returns the struct field for each shape field name.
+// When typ declares its fields in shape order they are used positionally,
+// otherwise each field is looked up by name.
+func objectStructFields(
+	typ reflect.Type,
+	names []string,
+	path Path,
+) ([]reflect.StructField, error) {
+	var resolved map[string]FieldInfo
+	if fieldResolver != nil {
+		resolved = fieldResolver(typ)
+	}
+
+	if resolved == nil {
+		if fields, ok := introspect.AlignedFields(typ, names); ok {
+			return fields, nil
+		}
+	}
+
+	fields := make([]reflect.StructField, len(names))
+	for i, name := range names {
+		if info, ok := resolved[name]; ok {
+			sf, ok := typ.FieldByName(info.Name)
+			if !ok {
+				return nil, fmt.Errorf(
+					"expected %v to have a field named %q for %q",
+					path, info.Name, name,
+				)
+			}
+			fields[i] = sf
+			continue
+		}
+
+		sf, ok := introspect.StructField(typ, name)
+		if !ok {
+			return nil, fmt.Errorf(
+				"expected %v to have a field named %q", path, name,
+			)
+		}
+		fields[i] = sf
+	}
+
+	return fields, nil
+}
+
 type objectDecoder struct {
 	id     types.UUID
 	fields []*DecoderField
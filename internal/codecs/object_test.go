@@ -0,0 +1,494 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type alignedObject struct {
+	ID    types.UUID `edgedb:"id"`
+	Name  string     `edgedb:"name"`
+	Email string     `edgedb:"email"`
+	Age   int64      `edgedb:"age"`
+}
+
+type misalignedObject struct {
+	Age   int64      `edgedb:"age"`
+	Email string     `edgedb:"email"`
+	Name  string     `edgedb:"name"`
+	ID    types.UUID `edgedb:"id"`
+}
+
+var objectDescriptor = descriptor.V2{
+	Type: descriptor.Object,
+	ID:   types.UUID{1},
+	Fields: []*descriptor.FieldV2{
+		{
+			Name:     "id",
+			Desc:     descriptor.V2{Type: descriptor.Scalar, ID: UUIDID},
+			Required: true,
+		},
+		{
+			Name:     "name",
+			Desc:     descriptor.V2{Type: descriptor.Scalar, ID: StrID},
+			Required: true,
+		},
+		{
+			Name:     "email",
+			Desc:     descriptor.V2{Type: descriptor.Scalar, ID: StrID},
+			Required: true,
+		},
+		{
+			Name:     "age",
+			Desc:     descriptor.V2{Type: descriptor.Scalar, ID: Int64ID},
+			Required: true,
+		},
+	},
+}
+
+var objectData = []byte{
+	0, 0, 0, 4, // element count
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 16, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16,
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 3, 'b', 'o', 'b',
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 5, 'b', '@', 'x', 'y', 'z',
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 42,
+}
+
+func TestDecodeObjectFieldOrder(t *testing.T) {
+	id := types.UUID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+	codec, err := BuildDecoderV2(
+		&objectDescriptor,
+		reflect.TypeOf(alignedObject{}),
+		Path("x"),
+	)
+	require.NoError(t, err)
+
+	var aligned alignedObject
+	err = codec.Decode(
+		buff.SimpleReader(objectData),
+		unsafe.Pointer(&aligned),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, alignedObject{id, "bob", "b@xyz", 42}, aligned)
+
+	codec, err = BuildDecoderV2(
+		&objectDescriptor,
+		reflect.TypeOf(misalignedObject{}),
+		Path("x"),
+	)
+	require.NoError(t, err)
+
+	var misaligned misalignedObject
+	err = codec.Decode(
+		buff.SimpleReader(objectData),
+		unsafe.Pointer(&misaligned),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, misalignedObject{42, "b@xyz", "bob", id}, misaligned)
+}
+
+func benchmarkObjectDecoder(b *testing.B, typ reflect.Type) {
+	out := reflect.New(typ).UnsafePointer()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		codec, err := BuildDecoderV2(&objectDescriptor, typ, Path("x"))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		err = codec.Decode(buff.SimpleReader(objectData), out)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkObjectDecoderAligned(b *testing.B) {
+	benchmarkObjectDecoder(b, reflect.TypeOf(alignedObject{}))
+}
+
+func BenchmarkObjectDecoderMisaligned(b *testing.B) {
+	benchmarkObjectDecoder(b, reflect.TypeOf(misalignedObject{}))
+}
+
+func TestDecodeObjectMultiProperty(t *testing.T) {
+	desc := descriptor.V2{
+		Type: descriptor.Object,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.FieldV2{
+			{
+				Name:     "name",
+				Desc:     descriptor.V2{Type: descriptor.Scalar, ID: StrID},
+				Required: true,
+			},
+			{
+				Name: "tags",
+				Desc: descriptor.V2{
+					Type: descriptor.Set,
+					ID:   types.UUID{2},
+					Fields: []*descriptor.FieldV2{{
+						Desc: descriptor.V2{
+							Type: descriptor.Scalar,
+							ID:   StrID,
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	type Post struct {
+		Name string   `edgedb:"name"`
+		Tags []string `edgedb:"tags"`
+	}
+
+	codec, err := BuildDecoderV2(&desc, reflect.TypeOf(Post{}), Path("x"))
+	require.NoError(t, err)
+
+	data := []byte{
+		0, 0, 0, 2, // element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 3, 'b', 'o', 'b',
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 32, // data length
+		0, 0, 0, 1, // dimension count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 2, // dimension upper
+		0, 0, 0, 1, // dimension lower
+		0, 0, 0, 1, 'a',
+		0, 0, 0, 3, 'x', 'y', 'z',
+	}
+
+	var result Post
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, Post{Name: "bob", Tags: []string{"a", "xyz"}}, result)
+
+	data = []byte{
+		0, 0, 0, 2, // element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 3, 'b', 'o', 'b',
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 12, // data length
+		0, 0, 0, 0, // dimension count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 0, // reserved
+	}
+
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, "bob", result.Name)
+	assert.Empty(t, result.Tags)
+
+	type SingleTag struct {
+		Name string `edgedb:"name"`
+		Tags string `edgedb:"tags"`
+	}
+
+	_, err = BuildDecoderV2(&desc, reflect.TypeOf(SingleTag{}), Path("x"))
+	assert.EqualError(t, err, "expected x.tags to be a Slice got string")
+}
+
+func TestDecodeObjectTypeLink(t *testing.T) {
+	desc := descriptor.V2{
+		Type: descriptor.Object,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.FieldV2{
+			{
+				Name:     "name",
+				Desc:     descriptor.V2{Type: descriptor.Scalar, ID: StrID},
+				Required: true,
+			},
+			{
+				Name: "__type__",
+				Desc: descriptor.V2{
+					Type: descriptor.Object,
+					ID:   types.UUID{2},
+					Fields: []*descriptor.FieldV2{{
+						Name: "name",
+						Desc: descriptor.V2{
+							Type: descriptor.Scalar,
+							ID:   StrID,
+						},
+						Required: true,
+					}},
+				},
+				Required: true,
+			},
+		},
+	}
+
+	type Foo struct {
+		Name string `edgedb:"name"`
+		Type struct {
+			Name string `edgedb:"name"`
+		} `edgedb:"__type__"`
+	}
+
+	codec, err := BuildDecoderV2(&desc, reflect.TypeOf(Foo{}), Path("x"))
+	require.NoError(t, err)
+
+	data := []byte{
+		0, 0, 0, 2, // element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 3, 'f', 'o', 'o',
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 24, // data length
+		0, 0, 0, 1, // element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 12,
+		'd', 'e', 'f', 'a', 'u', 'l', 't', ':', ':', 'F', 'o', 'o',
+	}
+
+	var result Foo
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, "foo", result.Name)
+	assert.Equal(t, "default::Foo", result.Type.Name)
+}
+
+func TestDecodeObjectFieldResolver(t *testing.T) {
+	type generatedUser struct {
+		Email    string
+		Age      int64
+		UserName string
+		UserID   types.UUID
+	}
+
+	SetFieldResolver(func(typ reflect.Type) map[string]FieldInfo {
+		if typ != reflect.TypeOf(generatedUser{}) {
+			return nil
+		}
+
+		return map[string]FieldInfo{
+			"id":    {Name: "UserID"},
+			"name":  {Name: "UserName"},
+			"email": {Name: "Email"},
+			"age":   {Name: "Age"},
+		}
+	})
+	defer SetFieldResolver(nil)
+
+	codec, err := BuildDecoderV2(
+		&objectDescriptor,
+		reflect.TypeOf(generatedUser{}),
+		Path("x"),
+	)
+	require.NoError(t, err)
+
+	var user generatedUser
+	err = codec.Decode(buff.SimpleReader(objectData), unsafe.Pointer(&user))
+	require.NoError(t, err)
+
+	id := types.UUID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	assert.Equal(t, generatedUser{
+		Email:    "b@xyz",
+		Age:      42,
+		UserName: "bob",
+		UserID:   id,
+	}, user)
+
+	// types unknown to the resolver are matched by tag and name.
+	codec, err = BuildDecoderV2(
+		&objectDescriptor,
+		reflect.TypeOf(alignedObject{}),
+		Path("x"),
+	)
+	require.NoError(t, err)
+
+	var aligned alignedObject
+	err = codec.Decode(buff.SimpleReader(objectData), unsafe.Pointer(&aligned))
+	require.NoError(t, err)
+	assert.Equal(t, alignedObject{id, "bob", "b@xyz", 42}, aligned)
+
+	SetFieldResolver(func(typ reflect.Type) map[string]FieldInfo {
+		return map[string]FieldInfo{"id": {Name: "Missing"}}
+	})
+
+	_, err = BuildDecoderV2(
+		&objectDescriptor,
+		reflect.TypeOf(generatedUser{}),
+		Path("x"),
+	)
+	assert.EqualError(t, err,
+		`expected x to have a field named "Missing" for "id"`)
+}
+
+// userID is decoded from its text representation.
+type userID string
+
+func (id *userID) UnmarshalText(text []byte) error {
+	*id = userID("user_" + string(text))
+	return nil
+}
+
+func TestDecodeObjectTextUnmarshaler(t *testing.T) {
+	type textObject struct {
+		ID    userID `edgedb:"id"`
+		Name  string `edgedb:"name"`
+		Email string `edgedb:"email"`
+		Age   userID `edgedb:"age"`
+	}
+
+	codec, err := BuildDecoderV2(
+		&objectDescriptor,
+		reflect.TypeOf(textObject{}),
+		Path("x"),
+	)
+	require.NoError(t, err)
+
+	var result textObject
+	err = codec.Decode(buff.SimpleReader(objectData), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, textObject{
+		ID:    "user_01020304-0506-0708-090a-0b0c0d0e0f10",
+		Name:  "bob",
+		Email: "b@xyz",
+		Age:   "user_42",
+	}, result)
+}
+
+var multiLinkDescriptor = descriptor.V2{
+	Type: descriptor.Object,
+	ID:   types.UUID{1},
+	Fields: []*descriptor.FieldV2{
+		{
+			Name:     "name",
+			Desc:     descriptor.V2{Type: descriptor.Scalar, ID: StrID},
+			Required: true,
+		},
+		{
+			Name: "friends",
+			Desc: descriptor.V2{
+				Type: descriptor.Set,
+				ID:   types.UUID{2},
+				Fields: []*descriptor.FieldV2{{
+					Desc: descriptor.V2{
+						Type: descriptor.Object,
+						ID:   types.UUID{3},
+						Fields: []*descriptor.FieldV2{{
+							Name: "name",
+							Desc: descriptor.V2{
+								Type: descriptor.Scalar,
+								ID:   StrID,
+							},
+							Required: true,
+						}},
+					},
+				}},
+			},
+		},
+	},
+}
+
+var multiLinkData = []byte{
+	0, 0, 0, 2, // element count
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 3, 'b', 'o', 'b',
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 77, // data length
+	0, 0, 0, 1, // dimension count
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 3, // dimension upper
+	0, 0, 0, 1, // dimension lower
+	0, 0, 0, 15, // element length
+	0, 0, 0, 1, // element count
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 3, 'a', 'm', 'y',
+	0, 0, 0, 15, // element length
+	0, 0, 0, 1, // element count
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 3, 'c', 'a', 't',
+	0, 0, 0, 15, // element length
+	0, 0, 0, 1, // element count
+	0, 0, 0, 0, // reserved
+	0, 0, 0, 3, 'd', 'a', 'n',
+}
+
+type friend struct {
+	Name string `edgedb:"name"`
+}
+
+type userWithFriends struct {
+	Name    string   `edgedb:"name"`
+	Friends []friend `edgedb:"friends"`
+}
+
+func TestDecodeObjectMultiLink(t *testing.T) {
+	codec, err := BuildDecoderV2(
+		&multiLinkDescriptor,
+		reflect.TypeOf(userWithFriends{}),
+		Path("x"),
+	)
+	require.NoError(t, err)
+
+	// All elements of the set are decoded by the same object decoder.
+	set := codec.(*objectDecoder).fields[1].decoder.(*setDecoder)
+	assert.IsType(t, &objectDecoder{}, set.child)
+
+	var result userWithFriends
+	err = codec.Decode(
+		buff.SimpleReader(multiLinkData),
+		unsafe.Pointer(&result),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, userWithFriends{
+		Name:    "bob",
+		Friends: []friend{{"amy"}, {"cat"}, {"dan"}},
+	}, result)
+}
+
+func BenchmarkObjectDecoderMultiLink(b *testing.B) {
+	codec, err := BuildDecoderV2(
+		&multiLinkDescriptor,
+		reflect.TypeOf(userWithFriends{}),
+		Path("x"),
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var result userWithFriends
+	out := unsafe.Pointer(&result)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err = codec.Decode(buff.SimpleReader(multiLinkData), out)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+)
+
+// decodesIntoPointer returns true if typ is decoded by a pointer decoder.
+// *big.Int is the type that bigint values are decoded into,
+// not a pointer to an optional value.
+func decodesIntoPointer(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Ptr && typ != bigIntType
+}
+
+func buildPointerDecoder(
+	desc descriptor.Descriptor,
+	typ reflect.Type,
+	path Path,
+) (Decoder, error) {
+	child, err := BuildDecoder(desc, typ.Elem(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPointerDecoder(child, typ), nil
+}
+
+func buildPointerDecoderV2(
+	desc *descriptor.V2,
+	typ reflect.Type,
+	path Path,
+) (Decoder, error) {
+	child, err := BuildDecoderV2(desc, typ.Elem(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPointerDecoder(child, typ), nil
+}
+
+// newPointerDecoder returns a decoder for the pointer type typ. Pointers to
+// types that json values are unmarshaled into are left to json.Unmarshal
+// so that a json null is decoded as nil.
+func newPointerDecoder(child Decoder, typ reflect.Type) Decoder {
+	switch c := child.(type) {
+	case *JSONCodec:
+		if c.typ != bytesType {
+			return &optionalNilableJSONDecoder{typ: typ}
+		}
+	case *optionalNilableJSONDecoder:
+		return &optionalNilableJSONDecoder{typ: typ}
+	}
+
+	return &pointerDecoder{child, typ.Elem()}
+}
+
+// pointerDecoder decodes into a pointer type. A new value is allocated for
+// each decoded element and missing values are decoded as nil.
+type pointerDecoder struct {
+	child Decoder
+	typ   reflect.Type
+}
+
+func (c *pointerDecoder) DescriptorID() types.UUID {
+	return c.child.DescriptorID()
+}
+
+func (c *pointerDecoder) Decode(r *buff.Reader, out unsafe.Pointer) error {
+	val := reflect.New(c.typ)
+	p := unsafe.Pointer(val.Pointer())
+	if err := c.child.Decode(r, p); err != nil {
+		return err
+	}
+
+	*(*unsafe.Pointer)(out) = p
+	return nil
+}
+
+func (c *pointerDecoder) DecodeMissing(out unsafe.Pointer) {
+	*(*unsafe.Pointer)(out) = nil
+}
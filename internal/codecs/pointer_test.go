@@ -0,0 +1,70 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBigIntPointer(t *testing.T) {
+	typ := reflect.TypeOf(&big.Int{})
+	data := []byte{
+		0, 1, // ndigits
+		0, 0, // weight
+		0, 0, // sign
+		0, 0, // reserved
+		0, 5, // digit
+	}
+
+	decoders := map[string]func() (Decoder, error){
+		"V1": func() (Decoder, error) {
+			desc := descriptor.Descriptor{
+				Type: descriptor.BaseScalar,
+				ID:   BigIntID,
+			}
+			return BuildDecoder(desc, typ, Path("x"))
+		},
+		"V2": func() (Decoder, error) {
+			desc := descriptor.V2{Type: descriptor.Scalar, ID: BigIntID}
+			return BuildDecoderV2(&desc, typ, Path("x"))
+		},
+	}
+
+	for name, build := range decoders {
+		t.Run(name, func(t *testing.T) {
+			codec, err := build()
+			require.NoError(t, err)
+
+			var result *big.Int
+			err = codec.Decode(
+				buff.SimpleReader(data),
+				unsafe.Pointer(&result),
+			)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, "5", result.String())
+		})
+	}
+}
@@ -301,6 +301,9 @@ func (c *rangeCodec) Decode(r *buff.Reader, out unsafe.Pointer) error {
 			}
 			// todo check that the  popped slice was consumed
 		}
+	} else {
+		// empty and unbounded ranges have no lower bound
+		c.child.DecodeMissing(pAdd(out, c.lowerOffset))
 	}
 
 	if hasUpper {
@@ -316,6 +319,9 @@ func (c *rangeCodec) Decode(r *buff.Reader, out unsafe.Pointer) error {
 			}
 			// todo check that the  popped slice was consumed
 		}
+	} else {
+		// empty and unbounded ranges have no upper bound
+		c.child.DecodeMissing(pAdd(out, c.upperOffset))
 	}
 
 	*(*bool)(pAdd(out, c.emptyOffset)) = empty
@@ -0,0 +1,100 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRangeInt64(t *testing.T) {
+	desc := descriptor.V2{
+		Type: descriptor.Range,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.FieldV2{{
+			Desc: descriptor.V2{Type: descriptor.Scalar, ID: Int64ID},
+		}},
+	}
+
+	codec, err := BuildDecoderV2(&desc, rangeInt64Type, Path("range"))
+	require.NoError(t, err)
+
+	one := types.NewOptionalInt64(1)
+	five := types.NewOptionalInt64(5)
+	missing := types.OptionalInt64{}
+
+	cases := []struct {
+		name     string
+		data     []byte
+		expected types.RangeInt64
+	}{
+		{
+			name:     "empty",
+			data:     []byte{rangeEmpty},
+			expected: types.NewRangeInt64(five, five, true, false),
+		},
+		{
+			name: "bounded",
+			data: []byte{
+				rangeLBInc,
+				0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1,
+				0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 5,
+			},
+			expected: types.NewRangeInt64(one, five, true, false),
+		},
+		{
+			name: "unbounded lower",
+			data: []byte{
+				rangeLBInf,
+				0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 5,
+			},
+			expected: types.NewRangeInt64(missing, five, false, false),
+		},
+		{
+			name: "unbounded upper",
+			data: []byte{
+				rangeLBInc | rangeUBInf,
+				0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1,
+			},
+			expected: types.NewRangeInt64(one, missing, true, false),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// Decode into a value that already has both bounds set
+			// to make sure missing bounds are cleared.
+			result := types.NewRangeInt64(one, five, true, true)
+			r := buff.SimpleReader(c.data)
+			err := codec.Decode(r, unsafe.Pointer(&result))
+			require.NoError(t, err)
+			assert.Equal(t, 0, len(r.Buf))
+			assert.True(t, reflect.DeepEqual(c.expected, result),
+				"expected %#v got %#v", c.expected, result)
+			assert.Equal(t, c.expected.IsEmpty(), result.IsEmpty())
+			assert.Equal(t, c.expected.Lower(), result.Lower())
+			assert.Equal(t, c.expected.Upper(), result.Upper())
+		})
+	}
+}
@@ -17,6 +17,7 @@
 package codecs
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
 	"unsafe"
@@ -49,7 +50,9 @@ type optionalStrMarshaler interface {
 	marshal.OptionalMarshaler
 }
 
-// Encode encodes a string.
+// Encode encodes a string. Values that are not a string or an
+// edgedb.OptionalStr are encoded with the first of these that applies:
+// MarshalEdgeDBStr, MarshalText, String or the value's underlying string.
 func (c *StrCodec) Encode(
 	w *buff.Writer,
 	val interface{},
@@ -72,10 +75,23 @@ func (c *StrCodec) Encode(
 			func() error { return missingValueError(in, path) })
 	case marshal.StrMarshaler:
 		return c.encodeMarshaler(w, in, path)
-	default:
-		return fmt.Errorf("expected %v to be string, edgedb.OptionalStr "+
-			"or StrMarshaler got %T", path, val)
+	case encoding.TextMarshaler:
+		text, err := in.MarshalText()
+		if err != nil {
+			return err
+		}
+		return c.encodeData(w, string(text))
+	case fmt.Stringer:
+		return c.encodeData(w, in.String())
 	}
+
+	if v := reflect.ValueOf(val); v.Kind() == reflect.String {
+		return c.encodeData(w, v.String())
+	}
+
+	return fmt.Errorf("expected %v to be string, edgedb.OptionalStr, "+
+		"StrMarshaler, encoding.TextMarshaler, fmt.Stringer "+
+		"or a string kind got %T", path, val)
 }
 
 func (c *StrCodec) encodeData(w *buff.Writer, data string) error {
@@ -0,0 +1,98 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"testing"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type strMarshalerColor struct{}
+
+func (strMarshalerColor) MarshalEdgeDBStr() ([]byte, error) {
+	return []byte("marshaler"), nil
+}
+
+func (strMarshalerColor) MarshalText() ([]byte, error) {
+	return []byte("text"), nil
+}
+
+func (strMarshalerColor) String() string { return "stringer" }
+
+type textMarshalerColor struct{}
+
+func (textMarshalerColor) MarshalText() ([]byte, error) {
+	return []byte("text"), nil
+}
+
+func (textMarshalerColor) String() string { return "stringer" }
+
+type stringerColor struct{}
+
+func (stringerColor) String() string { return "stringer" }
+
+type namedStringerColor string
+
+func (namedStringerColor) String() string { return "stringer" }
+
+type namedColor string
+
+func encodeStr(t *testing.T, val interface{}) (string, error) {
+	codec := &StrCodec{ID: StrID}
+	w := buff.NewWriter(nil)
+	w.BeginMessage(0)
+	if err := codec.Encode(w, val, Path("args[0]"), true); err != nil {
+		return "", err
+	}
+	w.EndMessage()
+
+	// message type, message length and data length
+	data := w.Unwrap()
+	require.GreaterOrEqual(t, len(data), 9)
+	return string(data[9:]), nil
+}
+
+func TestEncodeStrPrecedence(t *testing.T) {
+	samples := []struct {
+		name     string
+		val      interface{}
+		expected string
+	}{
+		{"string", "built-in", "built-in"},
+		{"marshaler", strMarshalerColor{}, "marshaler"},
+		{"text marshaler", textMarshalerColor{}, "text"},
+		{"stringer", stringerColor{}, "stringer"},
+		{"named stringer", namedStringerColor("red"), "stringer"},
+		{"named string", namedColor("red"), "red"},
+	}
+
+	for _, s := range samples {
+		t.Run(s.name, func(t *testing.T) {
+			data, err := encodeStr(t, s.val)
+			require.NoError(t, err)
+			assert.Equal(t, s.expected, data)
+		})
+	}
+
+	_, err := encodeStr(t, 42)
+	assert.EqualError(t, err, "expected args[0] to be string, "+
+		"edgedb.OptionalStr, StrMarshaler, encoding.TextMarshaler, "+
+		"fmt.Stringer or a string kind got int")
+}
@@ -95,6 +95,10 @@ func buildTupleDecoder(
 	typ reflect.Type,
 	path Path,
 ) (Decoder, error) {
+	if typ == tupleMapType {
+		return buildTupleMapDecoder(desc, path)
+	}
+
 	if typ.Kind() != reflect.Struct {
 		return nil, fmt.Errorf(
 			"expected %v to be a struct got %v", path, typ.Kind(),
@@ -143,6 +147,10 @@ func buildTupleDecoderV2(
 	typ reflect.Type,
 	path Path,
 ) (Decoder, error) {
+	if typ == tupleMapType {
+		return buildTupleMapDecoderV2(desc, path)
+	}
+
 	if typ.Kind() != reflect.Struct {
 		return nil, fmt.Errorf(
 			"expected %v to be a struct got %v", path, typ.Kind(),
@@ -206,6 +214,11 @@ func (c *tupleDecoder) Decode(r *buff.Reader, out unsafe.Pointer) error {
 
 		elmLen := r.PopUint32()
 		if elmLen == 0xffffffff {
+			// element length -1 means the element is null. Optional and
+			// pointer fields are marked missing, others are left unchanged.
+			if decoder, ok := field.decoder.(OptionalDecoder); ok {
+				decoder.DecodeMissing(pAdd(out, field.offset))
+			}
 			continue
 		}
 
@@ -240,3 +253,184 @@ func (c *optionalTupleDecoder) Decode(
 	method.Call([]reflect.Value{falseValue})
 	return c.tupleDecoder.Decode(r, out)
 }
+
+var (
+	tupleMapType = reflect.TypeOf(map[int]interface{}{})
+
+	// naturalScalarTypes are the go types that scalars are decoded into when
+	// the result type is not known in advance.
+	naturalScalarTypes = map[types.UUID]reflect.Type{
+		UUIDID:             uuidType,
+		StrID:              strType,
+		BytesID:            bytesType,
+		Int16ID:            int16Type,
+		Int32ID:            int32Type,
+		Int64ID:            int64Type,
+		Float32ID:          float32Type,
+		Float64ID:          float64Type,
+		DecimalID:          strType,
+		BoolID:             boolType,
+		DateTimeID:         dateTimeType,
+		LocalDTID:          localDateTimeType,
+		LocalDateID:        localDateType,
+		LocalTimeID:        localTimeType,
+		DurationID:         durationType,
+		JSONID:             bytesType,
+		BigIntID:           bigIntType,
+		RelativeDurationID: relativeDurationType,
+		DateDurationID:     dateDurationType,
+		MemoryID:           memoryType,
+	}
+)
+
+// naturalType returns the go type that values described by desc are decoded
+// into when decoding a tuple into a map[int]interface{}.
+func naturalType(desc descriptor.Descriptor, path Path) (reflect.Type, error) {
+	switch desc.Type {
+	case descriptor.Enum:
+		return strType, nil
+	case descriptor.BaseScalar, descriptor.Scalar:
+		if desc.Type == descriptor.Scalar {
+			desc = GetScalarDescriptor(desc)
+		}
+
+		if desc.ID == SequenceID {
+			return int64Type, nil
+		}
+
+		if typ, ok := naturalScalarTypes[desc.ID]; ok {
+			return typ, nil
+		}
+	case descriptor.Array:
+		typ, err := naturalType(desc.Fields[0].Desc, path.AddIndex(0))
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(typ), nil
+	case descriptor.Tuple:
+		return tupleMapType, nil
+	}
+
+	return nil, fmt.Errorf(
+		"cannot decode %v into map[int]interface{}: "+
+			"unsupported descriptor type %v", path, desc.Type)
+}
+
+// naturalTypeV2 returns the go type that values described by desc are
+// decoded into when decoding a tuple into a map[int]interface{}.
+func naturalTypeV2(desc *descriptor.V2, path Path) (reflect.Type, error) {
+	switch desc.Type {
+	case descriptor.Enum:
+		return strType, nil
+	case descriptor.BaseScalar, descriptor.Scalar:
+		desc = getEquivalentScalarDescriptor(GetScalarDescriptorV2(desc))
+		if typ, ok := naturalScalarTypes[desc.ID]; ok {
+			return typ, nil
+		}
+	case descriptor.Array:
+		typ, err := naturalTypeV2(&desc.Fields[0].Desc, path.AddIndex(0))
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(typ), nil
+	case descriptor.Tuple:
+		return tupleMapType, nil
+	}
+
+	return nil, fmt.Errorf(
+		"cannot decode %v into map[int]interface{}: "+
+			"unsupported descriptor type %v", path, desc.Type)
+}
+
+func buildTupleMapDecoder(
+	desc descriptor.Descriptor,
+	path Path,
+) (Decoder, error) {
+	fields := make([]*tupleMapField, len(desc.Fields))
+
+	for i, field := range desc.Fields {
+		typ, err := naturalType(field.Desc, path.AddIndex(i))
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := BuildDecoder(field.Desc, typ, path.AddIndex(i))
+		if err != nil {
+			return nil, err
+		}
+
+		fields[i] = &tupleMapField{typ: typ, decoder: child}
+	}
+
+	return &tupleMapDecoder{desc.ID, fields}, nil
+}
+
+func buildTupleMapDecoderV2(
+	desc *descriptor.V2,
+	path Path,
+) (Decoder, error) {
+	fields := make([]*tupleMapField, len(desc.Fields))
+
+	for i, field := range desc.Fields {
+		typ, err := naturalTypeV2(&field.Desc, path.AddIndex(i))
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := BuildDecoderV2(&field.Desc, typ, path.AddIndex(i))
+		if err != nil {
+			return nil, err
+		}
+
+		fields[i] = &tupleMapField{typ: typ, decoder: child}
+	}
+
+	return &tupleMapDecoder{desc.ID, fields}, nil
+}
+
+type tupleMapField struct {
+	typ     reflect.Type
+	decoder Decoder
+}
+
+// tupleMapDecoder decodes a tuple into a map[int]interface{}
+// keyed by element index.
+type tupleMapDecoder struct {
+	id     types.UUID
+	fields []*tupleMapField
+}
+
+func (c *tupleMapDecoder) DescriptorID() types.UUID { return c.id }
+
+func (c *tupleMapDecoder) Decode(r *buff.Reader, out unsafe.Pointer) error {
+	elmCount := int(int32(r.PopUint32()))
+	if elmCount != len(c.fields) {
+		return fmt.Errorf(
+			"wrong number of elements, expected %v got %v",
+			len(c.fields), elmCount)
+	}
+
+	result := (*map[int]interface{})(out)
+	if *result == nil {
+		*result = make(map[int]interface{}, elmCount)
+	}
+
+	for i, field := range c.fields {
+		r.Discard(4) // reserved
+
+		elmLen := r.PopUint32()
+		if elmLen == 0xffffffff {
+			(*result)[i] = nil
+			continue
+		}
+
+		val := reflect.New(field.typ)
+		err := field.decoder.Decode(r.PopSlice(elmLen), val.UnsafePointer())
+		if err != nil {
+			return err
+		}
+
+		(*result)[i] = val.Elem().Interface()
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecs
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/edgedb/edgedb-go/internal/buff"
+	"github.com/edgedb/edgedb-go/internal/descriptor"
+	types "github.com/edgedb/edgedb-go/internal/edgedbtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTupleNullElement(t *testing.T) {
+	type Tuple struct {
+		First  string `edgedb:"0"`
+		Second *int64 `edgedb:"1"`
+	}
+
+	desc := descriptor.V2{
+		Type: descriptor.Tuple,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.FieldV2{
+			{
+				Name: "0",
+				Desc: descriptor.V2{Type: descriptor.Scalar, ID: StrID},
+			},
+			{
+				Name: "1",
+				Desc: descriptor.V2{Type: descriptor.Scalar, ID: Int64ID},
+			},
+		},
+	}
+
+	codec, err := BuildDecoderV2(&desc, reflect.TypeOf(Tuple{}), Path("x"))
+	require.NoError(t, err)
+
+	five := int64(5)
+	result := Tuple{Second: &five}
+	data := []byte{
+		0, 0, 0, 2, // element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 2, 'h', 'i',
+		0, 0, 0, 0, // reserved
+		0xff, 0xff, 0xff, 0xff, // null
+	}
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, Tuple{First: "hi"}, result)
+
+	data = []byte{
+		0, 0, 0, 2, // element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 2, 'h', 'i',
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 7,
+	}
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	require.NotNil(t, result.Second)
+	assert.Equal(t, int64(7), *result.Second)
+	assert.Equal(t, int64(5), five, "the previous value must not be mutated")
+}
+
+func TestDecodeTupleIntoMap(t *testing.T) {
+	desc := descriptor.V2{
+		Type: descriptor.Tuple,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.FieldV2{
+			{
+				Name: "0",
+				Desc: descriptor.V2{Type: descriptor.Scalar, ID: StrID},
+			},
+			{
+				Name: "1",
+				Desc: descriptor.V2{Type: descriptor.Scalar, ID: Int64ID},
+			},
+			{
+				Name: "2",
+				Desc: descriptor.V2{Type: descriptor.Scalar, ID: BigIntID},
+			},
+		},
+	}
+
+	codec, err := BuildDecoderV2(
+		&desc,
+		reflect.TypeOf(map[int]interface{}{}),
+		Path("x"),
+	)
+	require.NoError(t, err)
+
+	data := []byte{
+		0, 0, 0, 3, // element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 2, 'h', 'i',
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 7,
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 10, 0, 1, 0, 0, 0, 0, 0, 0, 0, 3,
+	}
+
+	var result map[int]interface{}
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, map[int]interface{}{
+		0: "hi",
+		1: int64(7),
+		2: big.NewInt(3),
+	}, result)
+
+	data = []byte{
+		0, 0, 0, 3, // element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 0,
+		0, 0, 0, 0, // reserved
+		0xff, 0xff, 0xff, 0xff, // null
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 10, 0, 1, 0, 0, 0x40, 0, 0, 0, 0, 2,
+	}
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, map[int]interface{}{
+		0: "",
+		1: nil,
+		2: big.NewInt(-2),
+	}, result)
+}
+
+func TestDecodeTupleIntoMapUnsupported(t *testing.T) {
+	desc := descriptor.V2{
+		Type: descriptor.Tuple,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.FieldV2{{
+			Name: "0",
+			Desc: descriptor.V2{Type: descriptor.Object, ID: types.UUID{2}},
+		}},
+	}
+
+	_, err := BuildDecoderV2(
+		&desc,
+		reflect.TypeOf(map[int]interface{}{}),
+		Path("x"),
+	)
+	assert.EqualError(t, err, "cannot decode x[0] into "+
+		"map[int]interface{}: unsupported descriptor type Object")
+}
+
+func TestDecodeTupleIntoMapProtocolV1(t *testing.T) {
+	desc := descriptor.Descriptor{
+		Type: descriptor.Tuple,
+		ID:   types.UUID{1},
+		Fields: []*descriptor.Field{
+			{
+				Name: "0",
+				Desc: descriptor.Descriptor{
+					Type: descriptor.BaseScalar,
+					ID:   StrID,
+				},
+			},
+			{
+				Name: "1",
+				Desc: descriptor.Descriptor{
+					Type: descriptor.BaseScalar,
+					ID:   SequenceID,
+				},
+			},
+		},
+	}
+
+	codec, err := BuildDecoder(
+		desc,
+		reflect.TypeOf(map[int]interface{}{}),
+		Path("x"),
+	)
+	require.NoError(t, err)
+
+	data := []byte{
+		0, 0, 0, 2, // element count
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 2, 'h', 'i',
+		0, 0, 0, 0, // reserved
+		0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 7,
+	}
+
+	var result map[int]interface{}
+	err = codec.Decode(buff.SimpleReader(data), unsafe.Pointer(&result))
+	require.NoError(t, err)
+	assert.Equal(t, map[int]interface{}{0: "hi", 1: int64(7)}, result)
+
+	desc.Fields[1].Desc = descriptor.Descriptor{
+		Type: descriptor.Object,
+		ID:   types.UUID{2},
+	}
+	_, err = BuildDecoder(
+		desc,
+		reflect.TypeOf(map[int]interface{}{}),
+		Path("x"),
+	)
+	assert.EqualError(t, err, "cannot decode x[1] into "+
+		"map[int]interface{}: unsupported descriptor type Object")
+}
@@ -17,6 +17,7 @@
 package codecs
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
 	"unsafe"
@@ -185,6 +186,82 @@ func buildUnmarshalerV2(
 	return &decoder, true, nil
 }
 
+var textUnmarshalerType = getType((*encoding.TextUnmarshaler)(nil))
+
+// scalarTypes implement encoding.TextUnmarshaler but have their own codecs.
+// Decoding a different scalar type into them is a type mismatch.
+var scalarTypes = map[reflect.Type]bool{
+	uuidType:             true,
+	dateTimeType:         true,
+	localDateTimeType:    true,
+	localDateType:        true,
+	localTimeType:        true,
+	relativeDurationType: true,
+	dateDurationType:     true,
+	memoryType:           true,
+}
+
+// buildTextUnmarshaler builds a decoder for types that implement
+// encoding.TextUnmarshaler. The value is decoded with encoder, which must also
+// be a Codec, and passed to UnmarshalText as canonical text.
+func buildTextUnmarshaler(
+	encoder Encoder,
+	typ reflect.Type,
+) (Decoder, bool) {
+	if scalarTypes[typ] ||
+		!reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return nil, false
+	}
+
+	codec, ok := encoder.(Codec)
+	if !ok {
+		return nil, false
+	}
+
+	return &textUnmarshalerDecoder{codec, typ}, true
+}
+
+type textUnmarshalerDecoder struct {
+	codec Codec
+	typ   reflect.Type
+}
+
+func (c *textUnmarshalerDecoder) DescriptorID() types.UUID {
+	return c.codec.DescriptorID()
+}
+
+func (c *textUnmarshalerDecoder) Decode(
+	r *buff.Reader,
+	out unsafe.Pointer,
+) error {
+	val := reflect.New(c.codec.Type())
+	if err := c.codec.Decode(r, unsafe.Pointer(val.Pointer())); err != nil {
+		return err
+	}
+
+	text, err := canonicalText(val.Elem().Interface())
+	if err != nil {
+		return err
+	}
+
+	unmarshaler := reflect.NewAt(c.typ, out).Interface()
+	return unmarshaler.(encoding.TextUnmarshaler).UnmarshalText(text)
+}
+
+// canonicalText returns the text representation of a decoded scalar value.
+func canonicalText(val interface{}) ([]byte, error) {
+	switch v := val.(type) {
+	case encoding.TextMarshaler:
+		return v.MarshalText()
+	case []byte:
+		return v, nil
+	case fmt.Stringer:
+		return []byte(v.String()), nil
+	default:
+		return []byte(fmt.Sprint(v)), nil
+	}
+}
+
 type unmarshalerDecoder struct {
 	id         types.UUID
 	typ        reflect.Type
@@ -610,6 +610,9 @@ func ParseDuration(s string) (Duration, error) {
 // as an int64 microsecond count.
 type Duration int64
 
+// String returns d in the ISO 8601 format that EdgeDB uses to render
+// durations, e.g. PT1H2M3.5S. The components of negative durations are
+// negative, e.g. PT-1H-2M-3.5S. The result can be parsed by ParseDuration.
 func (d Duration) String() string {
 	if d == 0 {
 		return "PT0S"
@@ -660,6 +663,22 @@ func (d Duration) String() string {
 	return strings.Join(buf, "")
 }
 
+// IsZero returns true if d is the zero duration.
+func (d Duration) IsZero() bool { return d == 0 }
+
+// Compare returns -1 if d is shorter than other, 0 if they are equal
+// and +1 if d is longer than other.
+func (d Duration) Compare(other Duration) int {
+	switch {
+	case d < other:
+		return -1
+	case d > other:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // AsNanoseconds returns [time.Duration] represented as nanoseconds,
 // after transforming from Duration microsecond representation.
 // Returns an error if the Duration is too long and would cause an overflow of
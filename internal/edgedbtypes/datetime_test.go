@@ -759,6 +759,42 @@ func TestUnmarshalDuration(t *testing.T) {
 	}
 }
 
+func TestDurationString(t *testing.T) {
+	cases := []struct {
+		input    Duration
+		expected string
+	}{
+		{Duration(0), "PT0S"},
+		{Duration(1), "PT0.000001S"},
+		{Duration(3_723_500_000), "PT1H2M3.5S"},
+		{Duration(-3_723_500_000), "PT-1H-2M-3.5S"},
+		{Duration(-500_000), "PT-0.5S"},
+		{Duration(-60_000_000), "PT-1M"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expected, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.input.String())
+
+			parsed, err := ParseDuration(c.expected)
+			require.NoError(t, err)
+			assert.Equal(t, c.input, parsed)
+		})
+	}
+}
+
+func TestDurationCompare(t *testing.T) {
+	a := Duration(-1)
+	b := Duration(1_000_000)
+
+	assert.Equal(t, -1, a.Compare(b))
+	assert.Equal(t, 1, b.Compare(a))
+	assert.Equal(t, 0, b.Compare(Duration(1_000_000)))
+
+	assert.True(t, Duration(0).IsZero())
+	assert.False(t, a.IsZero())
+}
+
 func TestAsNanosecondsDuration(t *testing.T) {
 	var durationTruncMicroseconds = func(i int64) time.Duration {
 		return time.Duration(time.Duration(i).Microseconds() * 1000)
@@ -77,6 +77,21 @@ func (r RangeInt32) IncUpper() bool { return r.incUpper }
 // Empty returns true if the range is empty.
 func (r RangeInt32) Empty() bool { return r.empty }
 
+// IsEmpty returns true if the range is empty. It is equivalent to Empty.
+func (r RangeInt32) IsEmpty() bool { return r.empty }
+
+// IncludesLower returns true if the range has a lower bound
+// and the lower bound is inclusive.
+func (r RangeInt32) IncludesLower() bool {
+	return r.lower.isSet && r.incLower
+}
+
+// IncludesUpper returns true if the range has an upper bound
+// and the upper bound is inclusive.
+func (r RangeInt32) IncludesUpper() bool {
+	return r.upper.isSet && r.incUpper
+}
+
 type rangeInt32JSON struct {
 	Lower    OptionalInt32 `json:"lower"`
 	Upper    OptionalInt32 `json:"upper"`
@@ -234,6 +249,21 @@ func (r RangeInt64) IncUpper() bool { return r.incUpper }
 // Empty returns true if the range is empty.
 func (r RangeInt64) Empty() bool { return r.empty }
 
+// IsEmpty returns true if the range is empty. It is equivalent to Empty.
+func (r RangeInt64) IsEmpty() bool { return r.empty }
+
+// IncludesLower returns true if the range has a lower bound
+// and the lower bound is inclusive.
+func (r RangeInt64) IncludesLower() bool {
+	return r.lower.isSet && r.incLower
+}
+
+// IncludesUpper returns true if the range has an upper bound
+// and the upper bound is inclusive.
+func (r RangeInt64) IncludesUpper() bool {
+	return r.upper.isSet && r.incUpper
+}
+
 type rangeInt64JSON struct {
 	Lower    OptionalInt64 `json:"lower"`
 	Upper    OptionalInt64 `json:"upper"`
@@ -388,6 +418,21 @@ func (r RangeFloat32) IncUpper() bool { return r.incUpper }
 // Empty returns true if the range is empty.
 func (r RangeFloat32) Empty() bool { return r.empty }
 
+// IsEmpty returns true if the range is empty. It is equivalent to Empty.
+func (r RangeFloat32) IsEmpty() bool { return r.empty }
+
+// IncludesLower returns true if the range has a lower bound
+// and the lower bound is inclusive.
+func (r RangeFloat32) IncludesLower() bool {
+	return r.lower.isSet && r.incLower
+}
+
+// IncludesUpper returns true if the range has an upper bound
+// and the upper bound is inclusive.
+func (r RangeFloat32) IncludesUpper() bool {
+	return r.upper.isSet && r.incUpper
+}
+
 type rangeFloat32JSON struct {
 	Lower    OptionalFloat32 `json:"lower"`
 	Upper    OptionalFloat32 `json:"upper"`
@@ -542,6 +587,21 @@ func (r RangeFloat64) IncUpper() bool { return r.incUpper }
 // Empty returns true if the range is empty.
 func (r RangeFloat64) Empty() bool { return r.empty }
 
+// IsEmpty returns true if the range is empty. It is equivalent to Empty.
+func (r RangeFloat64) IsEmpty() bool { return r.empty }
+
+// IncludesLower returns true if the range has a lower bound
+// and the lower bound is inclusive.
+func (r RangeFloat64) IncludesLower() bool {
+	return r.lower.isSet && r.incLower
+}
+
+// IncludesUpper returns true if the range has an upper bound
+// and the upper bound is inclusive.
+func (r RangeFloat64) IncludesUpper() bool {
+	return r.upper.isSet && r.incUpper
+}
+
 type rangeFloat64JSON struct {
 	Lower    OptionalFloat64 `json:"lower"`
 	Upper    OptionalFloat64 `json:"upper"`
@@ -696,6 +756,21 @@ func (r RangeDateTime) IncUpper() bool { return r.incUpper }
 // Empty returns true if the range is empty.
 func (r RangeDateTime) Empty() bool { return r.empty }
 
+// IsEmpty returns true if the range is empty. It is equivalent to Empty.
+func (r RangeDateTime) IsEmpty() bool { return r.empty }
+
+// IncludesLower returns true if the range has a lower bound
+// and the lower bound is inclusive.
+func (r RangeDateTime) IncludesLower() bool {
+	return r.lower.isSet && r.incLower
+}
+
+// IncludesUpper returns true if the range has an upper bound
+// and the upper bound is inclusive.
+func (r RangeDateTime) IncludesUpper() bool {
+	return r.upper.isSet && r.incUpper
+}
+
 type rangeDateTimeJSON struct {
 	Lower    OptionalDateTime `json:"lower"`
 	Upper    OptionalDateTime `json:"upper"`
@@ -850,6 +925,21 @@ func (r RangeLocalDateTime) IncUpper() bool { return r.incUpper }
 // Empty returns true if the range is empty.
 func (r RangeLocalDateTime) Empty() bool { return r.empty }
 
+// IsEmpty returns true if the range is empty. It is equivalent to Empty.
+func (r RangeLocalDateTime) IsEmpty() bool { return r.empty }
+
+// IncludesLower returns true if the range has a lower bound
+// and the lower bound is inclusive.
+func (r RangeLocalDateTime) IncludesLower() bool {
+	return r.lower.isSet && r.incLower
+}
+
+// IncludesUpper returns true if the range has an upper bound
+// and the upper bound is inclusive.
+func (r RangeLocalDateTime) IncludesUpper() bool {
+	return r.upper.isSet && r.incUpper
+}
+
 type rangeLocalDateTimeJSON struct {
 	Lower    OptionalLocalDateTime `json:"lower"`
 	Upper    OptionalLocalDateTime `json:"upper"`
@@ -1009,6 +1099,21 @@ func (r RangeLocalDate) IncUpper() bool { return r.incUpper }
 // Empty returns true if the range is empty.
 func (r RangeLocalDate) Empty() bool { return r.empty }
 
+// IsEmpty returns true if the range is empty. It is equivalent to Empty.
+func (r RangeLocalDate) IsEmpty() bool { return r.empty }
+
+// IncludesLower returns true if the range has a lower bound
+// and the lower bound is inclusive.
+func (r RangeLocalDate) IncludesLower() bool {
+	return r.lower.isSet && r.incLower
+}
+
+// IncludesUpper returns true if the range has an upper bound
+// and the upper bound is inclusive.
+func (r RangeLocalDate) IncludesUpper() bool {
+	return r.upper.isSet && r.incUpper
+}
+
 type rangeLocalDateJSON struct {
 	Lower    OptionalLocalDate `json:"lower"`
 	Upper    OptionalLocalDate `json:"upper"`
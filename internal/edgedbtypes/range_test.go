@@ -0,0 +1,117 @@
+// This source file is part of the EdgeDB open source project.
+//
+// Copyright EdgeDB Inc. and the EdgeDB authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edgedbtypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeInt64Accessors(t *testing.T) {
+	cases := []struct {
+		name          string
+		input         RangeInt64
+		isEmpty       bool
+		lower         OptionalInt64
+		upper         OptionalInt64
+		includesLower bool
+		includesUpper bool
+	}{
+		{
+			name:    "empty",
+			input:   RangeInt64{empty: true},
+			isEmpty: true,
+		},
+		{
+			name: "empty from equal bounds",
+			input: NewRangeInt64(
+				NewOptionalInt64(3),
+				NewOptionalInt64(3),
+				true,
+				false,
+			),
+			isEmpty: true,
+		},
+		{
+			name: "bounded",
+			input: NewRangeInt64(
+				NewOptionalInt64(1),
+				NewOptionalInt64(10),
+				true,
+				false,
+			),
+			lower:         NewOptionalInt64(1),
+			upper:         NewOptionalInt64(10),
+			includesLower: true,
+		},
+		{
+			name: "unbounded lower",
+			input: NewRangeInt64(
+				OptionalInt64{},
+				NewOptionalInt64(10),
+				true,
+				false,
+			),
+			upper: NewOptionalInt64(10),
+		},
+		{
+			name: "unbounded upper",
+			input: NewRangeInt64(
+				NewOptionalInt64(1),
+				OptionalInt64{},
+				true,
+				true,
+			),
+			lower:         NewOptionalInt64(1),
+			includesLower: true,
+		},
+		{
+			name:  "unbounded",
+			input: RangeInt64{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.isEmpty, c.input.IsEmpty())
+			assert.Equal(t, c.input.Empty(), c.input.IsEmpty())
+			assert.Equal(t, c.lower, c.input.Lower())
+			assert.Equal(t, c.upper, c.input.Upper())
+			assert.Equal(t, c.includesLower, c.input.IncludesLower())
+			assert.Equal(t, c.includesUpper, c.input.IncludesUpper())
+		})
+	}
+}
+
+func TestRangeFloat64IncludesBounds(t *testing.T) {
+	r := NewRangeFloat64(
+		NewOptionalFloat64(1.5),
+		NewOptionalFloat64(2.5),
+		false,
+		true,
+	)
+
+	assert.False(t, r.IsEmpty())
+	assert.False(t, r.IncludesLower())
+	assert.True(t, r.IncludesUpper())
+
+	r = NewRangeFloat64(OptionalFloat64{}, OptionalFloat64{}, true, true)
+	assert.False(t, r.IsEmpty())
+	assert.False(t, r.IncludesLower())
+	assert.False(t, r.IncludesUpper())
+}
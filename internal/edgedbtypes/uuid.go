@@ -67,17 +67,50 @@ func (id UUID) MarshalText() ([]byte, error) {
 
 var errMalformedUUID = errors.New("malformed edgedb.UUID")
 
+// malformedUUIDError is returned when unmarshaling a malformed UUID.
+// It keeps the malformed value so that decoders can report where it was.
+type malformedUUIDError struct {
+	value string
+}
+
+func (e *malformedUUIDError) Error() string { return errMalformedUUID.Error() }
+
+func (e *malformedUUIDError) Is(target error) bool {
+	return target == errMalformedUUID
+}
+
+// MalformedValue returns the value that could not be parsed.
+func (e *malformedUUIDError) MalformedValue() string { return e.value }
+
 // UnmarshalText unmarshals the id from a string.
 func (id *UUID) UnmarshalText(b []byte) error {
 	tmp, err := ParseUUID(string(b))
 	if err != nil {
-		return err
+		return &malformedUUIDError{value: string(b)}
 	}
 
 	*id = tmp
 	return nil
 }
 
+// CompactUUID is a UUID represented in its compact form,
+// 32 hex digits without hyphens. It unmarshals from both forms.
+type CompactUUID UUID
+
+func (id CompactUUID) String() string {
+	return fmt.Sprintf("%x", id[:])
+}
+
+// MarshalText returns the id as a byte string.
+func (id CompactUUID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText unmarshals the id from a string.
+func (id *CompactUUID) UnmarshalText(b []byte) error {
+	return (*UUID)(id).UnmarshalText(b)
+}
+
 // NewOptionalUUID is a convenience function for creating an OptionalUUID with
 // its value set to v.
 func NewOptionalUUID(v UUID) OptionalUUID {
@@ -147,3 +147,26 @@ func TestUnmarshalOptionalUUID(t *testing.T) {
 		})
 	}
 }
+
+func TestCompactUUIDJSON(t *testing.T) {
+	expected := CompactUUID{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+	}
+
+	for _, s := range []string{
+		`"00010203-0405-0607-0809-0a0b0c0d0e0f"`,
+		`"000102030405060708090a0b0c0d0e0f"`,
+	} {
+		var id CompactUUID
+		require.NoError(t, json.Unmarshal([]byte(s), &id))
+		assert.Equal(t, expected, id)
+	}
+
+	bts, err := json.Marshal(expected)
+	require.NoError(t, err)
+	assert.Equal(t, `"000102030405060708090a0b0c0d0e0f"`, string(bts))
+
+	var id CompactUUID
+	err = json.Unmarshal([]byte(`"zz"`), &id)
+	assert.EqualError(t, err, "malformed edgedb.UUID")
+}
@@ -52,6 +52,46 @@ func StructField(t reflect.Type, name string) (reflect.StructField, bool) {
 	return reflect.StructField{}, false
 }
 
+// AlignedFields returns the fields of t when the i-th field of t is the field
+// that StructField would return for names[i]. This allows callers to skip
+// looking up each name when the struct was declared in the same order as the
+// names, which is common for generated code. If the fields are not aligned
+// ok is false.
+func AlignedFields(t reflect.Type, names []string) (
+	fields []reflect.StructField, ok bool,
+) {
+	if t.NumField() < len(names) {
+		return nil, false
+	}
+
+	tags := make(map[string]struct{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("edgedb")
+		if tag == "$inline" {
+			return nil, false
+		}
+		tags[tag] = struct{}{}
+	}
+
+	fields = make([]reflect.StructField, len(names))
+	for i, name := range names {
+		field := t.Field(i)
+		switch field.Tag.Get("edgedb") {
+		case name:
+		case "":
+			// A tag on some other field takes precedence over the name.
+			if _, ok := tags[name]; ok || field.Name != name {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+		fields[i] = field
+	}
+
+	return fields, true
+}
+
 // ValueOf returns the reflect.Value of an out parameter or an error
 // if the out parameter is not valid.
 func ValueOf(i interface{}) (reflect.Value, error) {
@@ -190,3 +190,59 @@ func TestValueOfSlice(t *testing.T) {
 	val.SetBytes([]byte{1, 2, 3})
 	assert.Equal(t, []byte{1, 2, 3}, thing)
 }
+
+type AlignedStruct struct {
+	ID    string `edgedb:"id"`
+	Name  string
+	Email string `edgedb:"email"`
+	Extra int
+}
+
+func TestAlignedFields(t *testing.T) {
+	typ := reflect.TypeOf(AlignedStruct{})
+
+	fields, ok := AlignedFields(typ, []string{"id", "Name", "email"})
+	require.True(t, ok)
+	require.Len(t, fields, 3)
+	for i, name := range []string{"id", "Name", "email"} {
+		expected, ok := StructField(typ, name)
+		require.True(t, ok)
+		assert.Equal(t, expected, fields[i])
+	}
+}
+
+func TestAlignedFieldsMisaligned(t *testing.T) {
+	cases := []struct {
+		name  string
+		typ   reflect.Type
+		names []string
+	}{
+		{
+			"reordered",
+			reflect.TypeOf(AlignedStruct{}),
+			[]string{"Name", "id", "email"},
+		},
+		{
+			"tag takes precedence over name",
+			reflect.TypeOf(SomeStruct{}),
+			[]string{"First", "Second", "Third"},
+		},
+		{
+			"inlined struct",
+			reflect.TypeOf(InlinedSomeStruct{}),
+			[]string{"First", "Second", "Zebra"},
+		},
+		{
+			"too many names",
+			reflect.TypeOf(InnerTwo{}),
+			[]string{"two", "three"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, ok := AlignedFields(c.typ, c.names)
+			assert.False(t, ok)
+		})
+	}
+}